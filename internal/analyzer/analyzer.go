@@ -15,16 +15,50 @@ const (
 	SeverityPass  Severity = "pass"
 	SeverityWarn  Severity = "warn"
 	SeverityError Severity = "error"
+	// SeverityInfo は rules.severity が info のルールの違反（終了コードに影響しない）
+	SeverityInfo Severity = "info"
+)
+
+// Result.Type の値。
+const (
+	TypeFile         = "file"
+	TypeDirectory    = "directory"
+	TypeSection      = "section"
+	TypeLineLength   = "line_length"
+	TypeFunction     = "function"
+	TypeDeclarations = "declarations"
+	TypeBudget       = "budget"
 )
 
 // Result は1つのチェック結果。
 type Result struct {
 	Path      string   `json:"path"`
-	Type      string   `json:"type"`      // "file" または "directory"
-	Lines     int      `json:"lines"`     // 実際の行数
-	Limit     int      `json:"limit"`     // 設定上限
-	Threshold int      `json:"threshold"` // warn/error 境界値
+	Type      string   `json:"type"`              // TypeFile / TypeDirectory / TypeSection 等
+	Section   string   `json:"section,omitempty"` // TypeSection の場合のセクション名
+	Lines     int      `json:"lines"`             // 実際の行数（TypeDeclarations の場合は宣言数、TypeBudget の場合は合計行数）
+	Limit     int      `json:"limit"`             // 設定上限
+	Threshold int      `json:"threshold"`         // warn/error 境界値
 	Severity  Severity `json:"severity"`
+	// Sections は TypeFile の結果に付与するセクションごとの行数の内訳
+	// （Vue/Svelte/Astro のブロック、Markdown のコードブロック、Notebook のセル種別）
+	Sections []SectionLines `json:"sections,omitempty"`
+	// LongestLine は TypeFile の結果に付与する最長行の文字数
+	LongestLine int `json:"longest_line,omitempty"`
+	// LineNumbers は TypeLineLength の結果で上限を超えた行の行番号（1 始まり）
+	LineNumbers []int `json:"line_numbers,omitempty"`
+	// Function と Line は TypeFunction の結果の関数名と開始行（1 始まり）
+	Function string `json:"function,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	// Headroom は TypeBudget の結果の残り行数（上限 - 合計。超過している場合は負）
+	Headroom int `json:"headroom,omitempty"`
+	// Test は TypeFile の結果でテストファイル（test_patterns にマッチ）かどうか
+	Test bool `json:"test,omitempty"`
+}
+
+// SectionLines はセクションごとの行数（count_mode に応じた値）。
+type SectionLines struct {
+	Name  string `json:"name"`
+	Lines int    `json:"lines"`
 }
 
 // AnalysisReport は全体のチェック結果。
@@ -33,46 +67,140 @@ type AnalysisReport struct {
 	Errors   int
 	Warnings int
 	Passed   int
+	// Infos は rules.severity が info のルールの違反の件数
+	Infos int
+	// TestFiles・TestLines と ProductionFiles・ProductionLines は、テストファイルとそれ以外の
+	// ファイルの件数・合計行数（count_mode に応じた値）
+	TestFiles       int
+	TestLines       int
+	ProductionFiles int
+	ProductionLines int
+	// Failure は終了コードを 1 にする理由（ApplyExitPolicy で設定する。該当しなければ nil）
+	Failure *Failure
+	// Skipped は走査時にチェック対象から外したファイル（自動生成ファイル等）
+	Skipped []scanner.SkippedFile
+}
+
+// SkippedCount は理由ごとのスキップ件数を返す。
+func (r *AnalysisReport) SkippedCount(reason string) int {
+	n := 0
+	for _, s := range r.Skipped {
+		if s.Reason == reason {
+			n++
+		}
+	}
+	return n
+}
+
+// TestRatio はテストコードと本番コードの行数の比（テスト / 本番）を返す。本番コードがない場合は 0。
+func (r *AnalysisReport) TestRatio() float64 {
+	if r.ProductionLines == 0 {
+		return 0
+	}
+	return float64(r.TestLines) / float64(r.ProductionLines)
 }
 
 // Analyze はカウント結果をルール設定と比較し、レポートを返す。
+// ネストした設定ファイルのスコープ内のファイル・ディレクトリは、そのスコープの設定（ScanResult の
+// FileEntry.Config・DirConfigs）で評価する。budgets はプロジェクトルートの設定 cfg のみを使う。
 func Analyze(counts []counter.LineCount, scanResult *scanner.ScanResult, cfg *config.Config) *AnalysisReport {
-	report := &AnalysisReport{}
+	report := &AnalysisReport{Skipped: scanResult.Skipped}
+
+	// ファイルごとのチェック
+	fileCfgs := fileConfigs(scanResult)
+	for _, lc := range counts {
+		analyzeFile(report, lc, scopeConfig(fileCfgs[lc.Path], cfg))
+	}
+
+	// ディレクトリごとのチェック（直下ファイルのみ集計）
+	analyzeDirectories(report, counts, scanResult, fileCfgs, cfg)
 
+	// パターンごとの合計行数のチェック
+	analyzeBudgets(report, counts, cfg)
+
+	return report
+}
+
+// fileConfigs はネストした設定ファイルのスコープ内のファイルについて、パスごとのスコープの設定を返す。
+func fileConfigs(scanResult *scanner.ScanResult) map[string]*config.Config {
+	cfgs := make(map[string]*config.Config)
+	for _, f := range scanResult.Files {
+		if f.Config != nil {
+			cfgs[f.Path] = f.Config
+		}
+	}
+	return cfgs
+}
+
+// scopeConfig はネストした設定ファイルのスコープの設定 scoped があればそれを、なければ cfg を返す。
+func scopeConfig(scoped, cfg *config.Config) *config.Config {
+	if scoped != nil {
+		return scoped
+	}
+	return cfg
+}
+
+// analyzeFile はファイルの行数と、セクション・行の長さ・関数・宣言数を上限と比較する。
+func analyzeFile(report *AnalysisReport, lc counter.LineCount, cfg *config.Config) {
 	maxFile := cfg.Rules.MaxLinesPerFile
-	maxDir := cfg.Rules.MaxLinesPerDirectory
-	thresholdPct := cfg.Rules.WarningThreshold
 	codeOnly := cfg.CountMode == config.CountModeCodeOnly
 
-	fileThreshold := calcThreshold(maxFile, thresholdPct)
-	dirThreshold := calcThreshold(maxDir, thresholdPct)
+	maxTestFile := cfg.Rules.MaxLinesPerTestFile
+	if maxTestFile <= 0 {
+		maxTestFile = maxFile
+	}
 
-	// ファイルごとのチェック
-	for _, lc := range counts {
-		lines := lc.TotalLines
-		if codeOnly {
-			lines = lc.CodeLines
-		}
+	lines := lc.TotalLines
+	if codeOnly {
+		lines = lc.CodeLines
+	}
 
-		severity := judgeSeverity(lines, maxFile, fileThreshold)
-		result := Result{
-			Path:      filepath.ToSlash(lc.Path),
-			Type:      "file",
-			Lines:     lines,
-			Limit:     maxFile,
-			Threshold: fileThreshold,
-			Severity:  severity,
-		}
-		report.Results = append(report.Results, result)
+	limit, band := maxFile, ruleBand(cfg, config.RuleMaxLinesPerFile, maxFile)
+	if lc.Test {
+		limit, band = maxTestFile, ruleBand(cfg, config.RuleMaxLinesPerTestFile, maxTestFile)
+		report.TestFiles++
+		report.TestLines += lines
+	} else {
+		report.ProductionFiles++
+		report.ProductionLines += lines
+	}
+
+	if !band.off() {
+		severity := band.judge(lines)
+		report.Results = append(report.Results, Result{
+			Path:        filepath.ToSlash(lc.Path),
+			Type:        TypeFile,
+			Lines:       lines,
+			Limit:       limit,
+			Threshold:   band.errorAbove,
+			Severity:    severity,
+			Sections:    sectionLines(lc.Sections, codeOnly),
+			LongestLine: lc.LongestLine,
+			Test:        lc.Test,
+		})
 		countSeverity(report, severity)
 	}
 
-	// ディレクトリごとのチェック（直下ファイルのみ集計）
-	dirLines := calcDirectoryLines(counts, codeOnly)
+	analyzeSections(report, lc, cfg)
+	analyzeLineLength(report, lc, cfg)
+	analyzeFunctions(report, lc, cfg)
+	analyzeDeclarations(report, lc, cfg)
+}
+
+// analyzeDirectories はディレクトリ直下のファイルの合計行数を max_lines_per_directory と比較する。
+func analyzeDirectories(report *AnalysisReport, counts []counter.LineCount, scanResult *scanner.ScanResult,
+	fileCfgs map[string]*config.Config, cfg *config.Config) {
+	dirLines := calcDirectoryLines(counts, fileCfgs, cfg)
 
 	for _, dir := range scanResult.Dirs {
+		dirCfg := scopeConfig(scanResult.DirConfigs[dir], cfg)
+		maxDir := dirCfg.Rules.MaxLinesPerDirectory
+		band := ruleBand(dirCfg, config.RuleMaxLinesPerDirectory, maxDir)
+		if band.off() {
+			continue
+		}
 		lines := dirLines[dir]
-		severity := judgeSeverity(lines, maxDir, dirThreshold)
+		severity := band.judge(lines)
 		dirPath := dir
 		if dirPath != "." {
 			dirPath = dirPath + "/"
@@ -81,54 +209,92 @@ func Analyze(counts []counter.LineCount, scanResult *scanner.ScanResult, cfg *co
 		}
 		result := Result{
 			Path:      dirPath,
-			Type:      "directory",
+			Type:      TypeDirectory,
 			Lines:     lines,
 			Limit:     maxDir,
-			Threshold: dirThreshold,
+			Threshold: band.errorAbove,
 			Severity:  severity,
 		}
 		report.Results = append(report.Results, result)
 		countSeverity(report, severity)
 	}
+}
 
-	return report
+// sectionLimits はセクション名ごとの上限設定を返す。上限が 0 のセクションはチェックしない。
+func sectionLimits(cfg *config.Config) map[string]int {
+	return map[string]int{
+		counter.SectionScript:   cfg.Rules.MaxScriptLinesPerComponent,
+		counter.SectionTemplate: cfg.Rules.MaxTemplateLinesPerComponent,
+	}
 }
 
-// calcThreshold は warn/error 境界値を計算する。
-func calcThreshold(limit int, thresholdPct int) int {
-	return limit + limit*thresholdPct/100
+// sectionRules はセクション名ごとのルール名（rules.warnings のキー）。
+var sectionRules = map[string]string{
+	counter.SectionScript:   config.RuleMaxScriptLinesPerComponent,
+	counter.SectionTemplate: config.RuleMaxTemplateLinesPerComponent,
 }
 
-// judgeSeverity は行数と上限・閾値から severity を判定する。
-func judgeSeverity(lines, limit, threshold int) Severity {
-	if lines <= limit {
-		return SeverityPass
-	}
-	if lines <= threshold {
-		return SeverityWarn
+// analyzeSections は Vue/Svelte/Astro のセクションごとの行数を上限と比較する。
+func analyzeSections(report *AnalysisReport, lc counter.LineCount, cfg *config.Config) {
+	limits := sectionLimits(cfg)
+	codeOnly := cfg.CountMode == config.CountModeCodeOnly
+
+	for _, sec := range lc.Sections {
+		limit := limits[sec.Name]
+		if limit <= 0 {
+			continue
+		}
+		lines := sec.TotalLines
+		if codeOnly {
+			lines = sec.CodeLines
+		}
+
+		band := ruleBand(cfg, sectionRules[sec.Name], limit)
+		if band.off() {
+			continue
+		}
+		severity := band.judge(lines)
+		report.Results = append(report.Results, Result{
+			Path:      filepath.ToSlash(lc.Path),
+			Type:      TypeSection,
+			Section:   sec.Name,
+			Lines:     lines,
+			Limit:     limit,
+			Threshold: band.errorAbove,
+			Severity:  severity,
+		})
+		countSeverity(report, severity)
 	}
-	return SeverityError
 }
 
-// countSeverity はレポートの集計値を更新する。
-func countSeverity(report *AnalysisReport, severity Severity) {
-	switch severity {
-	case SeverityPass:
-		report.Passed++
-	case SeverityWarn:
-		report.Warnings++
-	case SeverityError:
-		report.Errors++
+// sectionLines は count_mode に応じたセクションごとの行数を返す。
+func sectionLines(sections []counter.Section, codeOnly bool) []SectionLines {
+	if len(sections) == 0 {
+		return nil
+	}
+	out := make([]SectionLines, len(sections))
+	for i, sec := range sections {
+		lines := sec.TotalLines
+		if codeOnly {
+			lines = sec.CodeLines
+		}
+		out[i] = SectionLines{Name: sec.Name, Lines: lines}
 	}
+	return out
 }
 
-// calcDirectoryLines はディレクトリ直下のファイルの行数を集計する。
-func calcDirectoryLines(counts []counter.LineCount, codeOnly bool) map[string]int {
+// calcDirectoryLines はディレクトリ直下のファイルの行数を集計する。count_mode と
+// exclude_tests_from_directory はファイルのスコープの設定（fileCfgs になければ cfg）に従う。
+func calcDirectoryLines(counts []counter.LineCount, fileCfgs map[string]*config.Config, cfg *config.Config) map[string]int {
 	dirLines := make(map[string]int)
 	for _, lc := range counts {
+		fileCfg := scopeConfig(fileCfgs[lc.Path], cfg)
+		if fileCfg.Rules.ExcludeTestsFromDirectory && lc.Test {
+			continue
+		}
 		dir := filepath.ToSlash(filepath.Dir(lc.Path))
 		lines := lc.TotalLines
-		if codeOnly {
+		if fileCfg.CountMode == config.CountModeCodeOnly {
 			lines = lc.CodeLines
 		}
 		dirLines[dir] += lines
@@ -223,3 +223,59 @@ func findResult(report *AnalysisReport, path string) *Result {
 	}
 	return nil
 }
+
+func TestAnalyze_SectionLimits(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Rules.MaxScriptLinesPerComponent = 100
+	cfg.Rules.MaxTemplateLinesPerComponent = 50
+
+	counts := []counter.LineCount{
+		{Path: "src/App.vue", TotalLines: 250, CodeLines: 200, Sections: []counter.Section{
+			{Name: counter.SectionTemplate, TotalLines: 54, CodeLines: 50},
+			{Name: counter.SectionScript, TotalLines: 150, CodeLines: 120},
+			{Name: counter.SectionStyle, TotalLines: 40, CodeLines: 30},
+		}},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "src/App.vue", Dir: "src"}},
+		Dirs:  []string{"src"},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+
+	sections := make(map[string]Result)
+	for _, r := range report.Results {
+		if r.Type == TypeSection {
+			sections[r.Section] = r
+		}
+	}
+	// style には上限がないためチェックされない
+	assert.Len(t, sections, 2)
+	assert.Equal(t, SeverityError, sections[counter.SectionScript].Severity) // 150 > 110
+	assert.Equal(t, "src/App.vue", sections[counter.SectionScript].Path)
+	assert.Equal(t, SeverityWarn, sections[counter.SectionTemplate].Severity) // 50 < 54 <= 55
+}
+
+func TestAnalyze_FileResultIncludesSectionBreakdown(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.CountMode = config.CountModeCodeOnly
+
+	counts := []counter.LineCount{
+		{Path: "notes.ipynb", TotalLines: 40, CodeLines: 20, Sections: []counter.Section{
+			{Name: counter.SectionMarkdown, TotalLines: 15, CodeLines: 12},
+			{Name: counter.SectionCode, TotalLines: 25, CodeLines: 20},
+		}},
+		{Path: "main.go", TotalLines: 10, CodeLines: 8},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "notes.ipynb", Dir: "."}, {Path: "main.go", Dir: "."}},
+		Dirs:  []string{"."},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+
+	nb := findResult(report, "notes.ipynb")
+	assert.Equal(t, 20, nb.Lines)
+	assert.Equal(t, []SectionLines{{Name: "markdown", Lines: 12}, {Name: "code", Lines: 20}}, nb.Sections)
+	assert.Nil(t, findResult(report, "main.go").Sections)
+}
@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"path/filepath"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+	"github.com/ousiassllc/linterly/internal/scanner/gitfiles"
+)
+
+// analyzeBudgets は budgets のパターンごとに、マッチしたファイルの合計行数を上限と比較する。
+// パターンはファイルのプロジェクトルート相対のパス（チェック対象によらない）にマッチさせ、
+// マッチするファイルがない場合も合計 0 行として結果を出力する。
+func analyzeBudgets(report *AnalysisReport, counts []counter.LineCount, cfg *config.Config) {
+	codeOnly := cfg.CountMode == config.CountModeCodeOnly
+
+	if cfg.Rules.RuleSeverity(config.RuleBudgets) == config.SeverityOff {
+		return
+	}
+
+	for _, b := range cfg.Budgets {
+		total := 0
+		for _, lc := range counts {
+			if !gitfiles.MatchPattern(b.Path, filepath.ToSlash(lc.Path)) {
+				continue
+			}
+			if codeOnly {
+				total += lc.CodeLines
+			} else {
+				total += lc.TotalLines
+			}
+		}
+
+		band := ruleBand(cfg, config.RuleBudgets, b.MaxLines)
+		severity := band.judge(total)
+		report.Results = append(report.Results, Result{
+			Path:      b.Path,
+			Type:      TypeBudget,
+			Lines:     total,
+			Limit:     b.MaxLines,
+			Threshold: band.errorAbove,
+			Severity:  severity,
+			Headroom:  b.MaxLines - total,
+		})
+		countSeverity(report, severity)
+	}
+}
@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+	"github.com/ousiassllc/linterly/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_Budgets(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Budgets = []config.Budget{
+		{Path: "services/billing/**", MaxLines: 250}, // threshold = 275
+		{Path: "**", MaxLines: 1000},
+		{Path: "*.md", MaxLines: 50},
+		{Path: "services/search/**", MaxLines: 100},
+	}
+
+	counts := []counter.LineCount{
+		{Path: "services/billing/api.go", TotalLines: 200, CodeLines: 150},
+		{Path: "services/billing/db/store.go", TotalLines: 60, CodeLines: 40},
+		{Path: "services/users/api.go", TotalLines: 100, CodeLines: 80},
+		{Path: "docs/README.md", TotalLines: 80, CodeLines: 60},
+	}
+	scanResult := &scanner.ScanResult{}
+
+	byPath := func(report *AnalysisReport) map[string]Result {
+		m := make(map[string]Result)
+		for _, r := range report.Results {
+			if r.Type == TypeBudget {
+				m[r.Path] = r
+			}
+		}
+		return m
+	}
+
+	results := byPath(Analyze(counts, scanResult, cfg))
+	require.Len(t, results, 4)
+	assert.Equal(t, Result{Path: "services/billing/**", Type: TypeBudget, Lines: 260, Limit: 250, Threshold: 275,
+		Severity: SeverityWarn, Headroom: -10}, results["services/billing/**"])
+	assert.Equal(t, SeverityPass, results["**"].Severity)
+	assert.Equal(t, 440, results["**"].Lines)
+	assert.Equal(t, 560, results["**"].Headroom)
+	// スラッシュを含まないパターンは任意の階層のファイル名にマッチする
+	assert.Equal(t, SeverityError, results["*.md"].Severity)
+	// マッチするファイルがない場合は 0 行
+	assert.Equal(t, 0, results["services/search/**"].Lines)
+
+	// code_only の場合はコード行数を合計する
+	cfg.CountMode = config.CountModeCodeOnly
+	results = byPath(Analyze(counts, scanResult, cfg))
+	assert.Equal(t, 190, results["services/billing/**"].Lines)
+	assert.Equal(t, SeverityPass, results["services/billing/**"].Severity)
+}
+
+func TestAnalyze_BudgetsFromSubdirectoryTarget(t *testing.T) {
+	// サブディレクトリをチェック対象にしても、パターンはプロジェクトルート相対のパスにマッチする
+	root := t.TempDir()
+	billing := filepath.Join(root, "services", "billing")
+	require.NoError(t, os.MkdirAll(billing, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(billing, "api.go"), []byte(strings.Repeat("x\n", 30)), 0644))
+
+	cfg := newTestConfig()
+	cfg.ProjectRoot = root
+	cfg.Budgets = []config.Budget{{Path: "services/billing/**", MaxLines: 20}}
+
+	scanResult, err := scanner.Scan(billing, cfg)
+	require.NoError(t, err)
+	counts := make([]counter.LineCount, len(scanResult.Files))
+	for i, f := range scanResult.Files {
+		lc, err := counter.CountFile(filepath.Join(scanResult.Base, f.Path), counter.NewOptions(cfg))
+		require.NoError(t, err)
+		lc.Path = f.Path
+		counts[i] = *lc
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	var budget *Result
+	for i, r := range report.Results {
+		if r.Type == TypeBudget {
+			budget = &report.Results[i]
+		}
+	}
+	require.NotNil(t, budget)
+	assert.Equal(t, 30, budget.Lines)
+	assert.Equal(t, SeverityError, budget.Severity)
+}
@@ -0,0 +1,30 @@
+package analyzer
+
+import (
+	"path/filepath"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+)
+
+// analyzeDeclarations はファイルのトップレベルの宣言数を max_declarations_per_file と比較する。
+// 宣言の検出に対応していない言語のファイル（Declarations が nil）はチェックしない。
+func analyzeDeclarations(report *AnalysisReport, lc counter.LineCount, cfg *config.Config) {
+	limit := cfg.Rules.MaxDeclarationsPerFile
+	band := ruleBand(cfg, config.RuleMaxDeclarationsPerFile, limit)
+	if limit <= 0 || lc.Declarations == nil || band.off() {
+		return
+	}
+
+	count := len(lc.Declarations)
+	severity := band.judge(count)
+	report.Results = append(report.Results, Result{
+		Path:      filepath.ToSlash(lc.Path),
+		Type:      TypeDeclarations,
+		Lines:     count,
+		Limit:     limit,
+		Threshold: band.errorAbove,
+		Severity:  severity,
+	})
+	countSeverity(report, severity)
+}
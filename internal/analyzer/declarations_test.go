@@ -0,0 +1,43 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/counter"
+	"github.com/ousiassllc/linterly/internal/counter/funcs"
+	"github.com/ousiassllc/linterly/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_MaxDeclarationsPerFile(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Rules.MaxDeclarationsPerFile = 10 // threshold = 11
+
+	decls := func(n int) []funcs.Declaration {
+		return make([]funcs.Declaration, n)
+	}
+	counts := []counter.LineCount{
+		{Path: "a.go", TotalLines: 10, CodeLines: 10, Declarations: decls(3)},
+		{Path: "b.go", TotalLines: 10, CodeLines: 10, Declarations: decls(11)},
+		{Path: "c.go", TotalLines: 10, CodeLines: 10, Declarations: decls(35)},
+		{Path: "d.lua", TotalLines: 10, CodeLines: 10}, // 宣言の検出に対応していない言語
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "a.go", Dir: "."}, {Path: "b.go", Dir: "."}, {Path: "c.go", Dir: "."}, {Path: "d.lua", Dir: "."}},
+		Dirs:  []string{"."},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	var results []Result
+	for _, r := range report.Results {
+		if r.Type == TypeDeclarations {
+			results = append(results, r)
+		}
+	}
+	require.Len(t, results, 3)
+	assert.Equal(t, Result{Path: "a.go", Type: TypeDeclarations, Lines: 3, Limit: 10, Threshold: 11, Severity: SeverityPass}, results[0])
+	assert.Equal(t, SeverityWarn, results[1].Severity)
+	assert.Equal(t, SeverityError, results[2].Severity)
+	assert.Equal(t, 35, results[2].Lines)
+}
@@ -0,0 +1,35 @@
+package analyzer
+
+import "github.com/ousiassllc/linterly/internal/config"
+
+// 終了コードを 1 にする理由（Failure.Reason の値）。
+const (
+	FailReasonErrors      = "errors"       // error の違反がある
+	FailReasonWarnings    = "warnings"     // fail_on が warn / info で、warn の違反がある
+	FailReasonInfos       = "info"         // fail_on が info で、info の違反がある
+	FailReasonMaxWarnings = "max_warnings" // warn の件数が max_warnings を超えた
+)
+
+// Failure は終了コードを 1 にする理由。
+type Failure struct {
+	Reason string `json:"reason"`
+	// Count は理由となった違反の件数
+	Count int `json:"count"`
+	// Limit は FailReasonMaxWarnings の場合の max_warnings の値
+	Limit int `json:"limit,omitempty"`
+}
+
+// ApplyExitPolicy は fail_on と max_warnings から終了コードを 1 にするかを判定し、
+// 理由を Failure に設定する。該当しない場合は Failure を nil のままにする。
+func (r *AnalysisReport) ApplyExitPolicy(failOn string, maxWarnings int) {
+	switch {
+	case r.Errors > 0:
+		r.Failure = &Failure{Reason: FailReasonErrors, Count: r.Errors}
+	case (failOn == config.FailOnWarn || failOn == config.FailOnInfo) && r.Warnings > 0:
+		r.Failure = &Failure{Reason: FailReasonWarnings, Count: r.Warnings}
+	case failOn == config.FailOnInfo && r.Infos > 0:
+		r.Failure = &Failure{Reason: FailReasonInfos, Count: r.Infos}
+	case maxWarnings >= 0 && r.Warnings > maxWarnings:
+		r.Failure = &Failure{Reason: FailReasonMaxWarnings, Count: r.Warnings, Limit: maxWarnings}
+	}
+}
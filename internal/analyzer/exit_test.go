@@ -0,0 +1,37 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyExitPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		report      AnalysisReport
+		failOn      string
+		maxWarnings int
+		want        *Failure
+	}{
+		{"error があれば失敗", AnalysisReport{Errors: 2, Warnings: 1}, config.FailOnError, -1, &Failure{Reason: FailReasonErrors, Count: 2}},
+		{"warn のみは成功", AnalysisReport{Warnings: 3, Infos: 1}, config.FailOnError, -1, nil},
+		{"未指定は error と同じ", AnalysisReport{Warnings: 3}, "", -1, nil},
+		{"fail_on: warn で warn があれば失敗", AnalysisReport{Warnings: 3}, config.FailOnWarn, -1, &Failure{Reason: FailReasonWarnings, Count: 3}},
+		{"fail_on: warn で info のみは成功", AnalysisReport{Infos: 2}, config.FailOnWarn, -1, nil},
+		{"fail_on: info で info があれば失敗", AnalysisReport{Infos: 2}, config.FailOnInfo, -1, &Failure{Reason: FailReasonInfos, Count: 2}},
+		{"fail_on: info でも warn を優先", AnalysisReport{Warnings: 1, Infos: 2}, config.FailOnInfo, -1, &Failure{Reason: FailReasonWarnings, Count: 1}},
+		{"max_warnings 以下は成功", AnalysisReport{Warnings: 5}, config.FailOnError, 5, nil},
+		{"max_warnings を超えると失敗", AnalysisReport{Warnings: 6}, config.FailOnError, 5, &Failure{Reason: FailReasonMaxWarnings, Count: 6, Limit: 5}},
+		{"max_warnings: 0 は warn 1 件で失敗", AnalysisReport{Warnings: 1}, config.FailOnError, 0, &Failure{Reason: FailReasonMaxWarnings, Count: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := tt.report
+			report.ApplyExitPolicy(tt.failOn, tt.maxWarnings)
+			assert.Equal(t, tt.want, report.Failure)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package analyzer
+
+import (
+	"path/filepath"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+)
+
+// analyzeFunctions は関数ごとの行数を max_lines_per_function と比較する。
+// 関数は1ファイルに多数あるため、上限を超えた（warn / error の）関数のみ結果に含める。
+func analyzeFunctions(report *AnalysisReport, lc counter.LineCount, cfg *config.Config) {
+	limit := cfg.Rules.MaxLinesPerFunction
+	band := ruleBand(cfg, config.RuleMaxLinesPerFunction, limit)
+	if limit <= 0 || band.off() {
+		return
+	}
+	codeOnly := cfg.CountMode == config.CountModeCodeOnly
+
+	for _, fn := range lc.Functions {
+		lines := fn.TotalLines
+		if codeOnly {
+			lines = fn.CodeLines
+		}
+		severity := band.judge(lines)
+		if severity == SeverityPass {
+			continue
+		}
+		report.Results = append(report.Results, Result{
+			Path:      filepath.ToSlash(lc.Path),
+			Type:      TypeFunction,
+			Lines:     lines,
+			Limit:     limit,
+			Threshold: band.errorAbove,
+			Severity:  severity,
+			Function:  fn.Name,
+			Line:      fn.Line,
+		})
+		countSeverity(report, severity)
+	}
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+	"github.com/ousiassllc/linterly/internal/counter/funcs"
+	"github.com/ousiassllc/linterly/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// functionResults は TypeFunction の結果を返すヘルパー。
+func functionResults(report *AnalysisReport) []Result {
+	var results []Result
+	for _, r := range report.Results {
+		if r.Type == TypeFunction {
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+func TestAnalyze_MaxLinesPerFunction(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Rules.MaxLinesPerFunction = 50 // threshold = 55
+
+	counts := []counter.LineCount{
+		{Path: "a.go", TotalLines: 200, CodeLines: 150, Functions: []funcs.Function{
+			{Name: "small", Line: 3, TotalLines: 20, CodeLines: 18},
+			{Name: "(*T).Medium", Line: 30, TotalLines: 53, CodeLines: 40},
+			{Name: "Large", Line: 90, TotalLines: 100, CodeLines: 52},
+		}},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "a.go", Dir: "."}},
+		Dirs:  []string{"."},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	results := functionResults(report)
+	require.Len(t, results, 2, "上限以内の関数は結果に含めない")
+	assert.Equal(t, Result{Path: "a.go", Type: TypeFunction, Lines: 53, Limit: 50, Threshold: 55,
+		Severity: SeverityWarn, Function: "(*T).Medium", Line: 30}, results[0])
+	assert.Equal(t, SeverityError, results[1].Severity)
+	assert.Equal(t, "Large", results[1].Function)
+	assert.Equal(t, 1, report.Warnings)
+	assert.Equal(t, 1, report.Errors)
+
+	// code_only ではコメント・空行を除いた行数で判定する
+	cfg.CountMode = config.CountModeCodeOnly
+	report = Analyze(counts, scanResult, cfg)
+	results = functionResults(report)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Large", results[0].Function)
+	assert.Equal(t, 52, results[0].Lines)
+	assert.Equal(t, SeverityWarn, results[0].Severity)
+
+	// 上限が 0 の場合はチェックしない
+	cfg.Rules.MaxLinesPerFunction = 0
+	assert.Empty(t, functionResults(Analyze(counts, scanResult, cfg)))
+}
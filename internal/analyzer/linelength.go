@@ -0,0 +1,35 @@
+package analyzer
+
+import (
+	"path/filepath"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+)
+
+// analyzeLineLength はファイル内の最も幅の広い行を max_line_length と比較する。
+// 上限を超えた行（lc.LongLines）があるファイルのみ結果を追加し、Lines には最大の表示幅、
+// LineNumbers には上限を超えたすべての行の行番号を設定する。warning_mode: early でも
+// 上限以下の幅は報告する行がないため warn にしない。
+func analyzeLineLength(report *AnalysisReport, lc counter.LineCount, cfg *config.Config) {
+	limit := cfg.Rules.MaxLineLength
+	band := ruleBand(cfg, config.RuleMaxLineLength, limit)
+	if limit <= 0 || band.off() || len(lc.LongLines) == 0 {
+		return
+	}
+
+	severity := band.judge(lc.MaxLineWidth)
+	if severity == SeverityPass {
+		return
+	}
+	report.Results = append(report.Results, Result{
+		Path:        filepath.ToSlash(lc.Path),
+		Type:        TypeLineLength,
+		Lines:       lc.MaxLineWidth,
+		Limit:       limit,
+		Threshold:   band.errorAbove,
+		Severity:    severity,
+		LineNumbers: lc.LongLines,
+	})
+	countSeverity(report, severity)
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+	"github.com/ousiassllc/linterly/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineLengthResults は TypeLineLength の結果をパスごとに返すヘルパー。
+func lineLengthResults(report *AnalysisReport) map[string]Result {
+	results := make(map[string]Result)
+	for _, r := range report.Results {
+		if r.Type == TypeLineLength {
+			results[r.Path] = r
+		}
+	}
+	return results
+}
+
+func TestAnalyze_MaxLineLength(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Rules.MaxLineLength = 100 // threshold = 110
+
+	counts := []counter.LineCount{
+		{Path: "a.go", TotalLines: 10, CodeLines: 10, MaxLineWidth: 80},
+		{Path: "b.go", TotalLines: 10, CodeLines: 10, MaxLineWidth: 105, LongLines: []int{3}},
+		{Path: "c.go", TotalLines: 10, CodeLines: 10, MaxLineWidth: 140, LongLines: []int{2, 7, 9}},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "a.go", Dir: "."}, {Path: "b.go", Dir: "."}, {Path: "c.go", Dir: "."}},
+		Dirs:  []string{"."},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	results := lineLengthResults(report)
+	require.Len(t, results, 2)
+
+	// 上限内のファイルは結果に含めない
+	assert.NotContains(t, results, "a.go")
+
+	assert.Equal(t, SeverityWarn, results["b.go"].Severity)
+	assert.Equal(t, []int{3}, results["b.go"].LineNumbers)
+
+	assert.Equal(t, SeverityError, results["c.go"].Severity)
+	assert.Equal(t, 140, results["c.go"].Lines)
+	assert.Equal(t, 100, results["c.go"].Limit)
+	assert.Equal(t, []int{2, 7, 9}, results["c.go"].LineNumbers)
+
+	// ファイル 3 + ディレクトリ 1（行幅は違反したファイルのみ集計する）
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 1, report.Warnings)
+	assert.Equal(t, 4, report.Passed)
+}
+
+func TestAnalyze_MaxLineLengthEarly(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Rules.MaxLineLength = 10
+	cfg.Rules.WarningMode = config.WarningModeEarly
+	cfg.Rules.WarningThreshold = 20 // warn: 8 桁超
+
+	counts := []counter.LineCount{
+		{Path: "a.go", TotalLines: 3, CodeLines: 3, MaxLineWidth: 9},
+		{Path: "b.go", TotalLines: 3, CodeLines: 3, MaxLineWidth: 12, LongLines: []int{2}},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "a.go", Dir: "."}, {Path: "b.go", Dir: "."}},
+		Dirs:  []string{"."},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	results := lineLengthResults(report)
+	require.Len(t, results, 1)
+
+	// 上限を超えた行がなければ、early の warn の範囲でも結果に含めない（行番号のない警告にしない）
+	assert.NotContains(t, results, "a.go")
+	assert.Equal(t, SeverityError, results["b.go"].Severity)
+	assert.Equal(t, []int{2}, results["b.go"].LineNumbers)
+}
+
+func TestAnalyze_MaxLineLengthDisabled(t *testing.T) {
+	cfg := newTestConfig()
+
+	counts := []counter.LineCount{{Path: "a.go", TotalLines: 10, CodeLines: 10, MaxLineWidth: 500, LongLines: []int{1}}}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "a.go", Dir: "."}},
+		Dirs:  []string{"."},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	assert.Empty(t, lineLengthResults(report))
+}
@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+	"github.com/ousiassllc/linterly/internal/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze_NestedScopes(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Budgets = []config.Budget{{Path: "**", MaxLines: 1000}}
+
+	// apps/web はネストした設定ファイルのスコープ（code_only、上限 500、ディレクトリは info）
+	web := newTestConfig()
+	web.CountMode = config.CountModeCodeOnly
+	web.Rules.MaxLinesPerFile = 500
+	web.Rules.MaxLinesPerDirectory = 300
+	web.Rules.Severity = map[string]string{config.RuleMaxLinesPerDirectory: config.SeverityInfo}
+	web.Budgets = []config.Budget{{Path: "**", MaxLines: 1}}
+
+	counts := []counter.LineCount{
+		{Path: "api/server.go", TotalLines: 400, CodeLines: 350},
+		{Path: "apps/web/app.ts", TotalLines: 480, CodeLines: 420},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{
+			{Path: "api/server.go", Dir: "api"},
+			{Path: "apps/web/app.ts", Dir: "apps/web", Config: web},
+		},
+		Dirs:       []string{"api", "apps/web"},
+		DirConfigs: map[string]*config.Config{"apps/web": web},
+	}
+
+	results := make(map[string]Result)
+	for _, r := range Analyze(counts, scanResult, cfg).Results {
+		results[r.Path] = r
+	}
+	// ファイル・ディレクトリはそれぞれのスコープの設定で評価する
+	assert.Equal(t, SeverityError, results["api/server.go"].Severity)
+	assert.Equal(t, Result{Path: "apps/web/app.ts", Type: TypeFile, Lines: 420, Limit: 500, Threshold: 550,
+		Severity: SeverityPass}, results["apps/web/app.ts"])
+	assert.Equal(t, Result{Path: "apps/web/", Type: TypeDirectory, Lines: 420, Limit: 300, Threshold: 330,
+		Severity: SeverityInfo}, results["apps/web/"])
+	assert.Equal(t, 400, results["api/"].Lines)
+	// budgets はプロジェクトルートの設定のみ（行数はファイルごとのカウントモードではなくルートの設定に従う）
+	assert.Equal(t, 880, results["**"].Lines)
+}
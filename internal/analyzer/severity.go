@@ -0,0 +1,67 @@
+package analyzer
+
+import "github.com/ousiassllc/linterly/internal/config"
+
+// warningBand は1つのルールの warn / error の境界。
+// 値が warnAbove を超えると warn、errorAbove（Result.Threshold）を超えると error になる。
+type warningBand struct {
+	warnAbove  int
+	errorAbove int
+	// level は rules.severity の違反レベル（config.SeverityError 等）
+	level string
+}
+
+// newWarningBand は上限とルールの警告の設定から warn / error の境界を計算する。
+// grace では上限を超えてから margin の範囲を warn とし、early では上限の手前 margin の範囲を
+// warn、上限を超えたら error とする。
+func newWarningBand(limit int, policy config.WarningPolicy) warningBand {
+	margin := policy.Margin(limit)
+	if policy.Mode == config.WarningModeEarly {
+		return warningBand{warnAbove: max(limit-margin, 0), errorAbove: limit}
+	}
+	return warningBand{warnAbove: limit, errorAbove: limit + margin}
+}
+
+// ruleBand はルール rule の上限 limit に対する warn / error の境界と違反レベルを返す。
+func ruleBand(cfg *config.Config, rule string, limit int) warningBand {
+	band := newWarningBand(limit, cfg.Rules.WarningPolicy(rule))
+	band.level = cfg.Rules.RuleSeverity(rule)
+	return band
+}
+
+// off はルールのチェックを行わない（rules.severity が off）かどうかを返す。
+func (b warningBand) off() bool {
+	return b.level == config.SeverityOff
+}
+
+// judge は値から severity を判定する。違反は rules.severity の違反レベルに合わせて
+// warn（error を warn に下げる）または info に置き換える。
+func (b warningBand) judge(value int) Severity {
+	if value <= b.warnAbove {
+		return SeverityPass
+	}
+	switch b.level {
+	case config.SeverityWarn:
+		return SeverityWarn
+	case config.SeverityInfo:
+		return SeverityInfo
+	}
+	if value <= b.errorAbove {
+		return SeverityWarn
+	}
+	return SeverityError
+}
+
+// countSeverity はレポートの集計値を更新する。
+func countSeverity(report *AnalysisReport, severity Severity) {
+	switch severity {
+	case SeverityPass:
+		report.Passed++
+	case SeverityWarn:
+		report.Warnings++
+	case SeverityError:
+		report.Errors++
+	case SeverityInfo:
+		report.Infos++
+	}
+}
@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/counter"
+	"github.com/ousiassllc/linterly/internal/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze_TestFiles(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Rules.MaxLinesPerTestFile = 600 // threshold = 660
+	cfg.Rules.MaxLinesPerDirectory = 1000
+
+	counts := []counter.LineCount{
+		{Path: "pkg/server.go", TotalLines: 320, CodeLines: 300},
+		{Path: "pkg/server_test.go", TotalLines: 500, CodeLines: 450, Test: true},
+		{Path: "pkg/client_test.go", TotalLines: 700, CodeLines: 650, Test: true},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{
+			{Path: "pkg/server.go", Dir: "pkg"},
+			{Path: "pkg/server_test.go", Dir: "pkg", Test: true},
+			{Path: "pkg/client_test.go", Dir: "pkg", Test: true},
+		},
+		Dirs: []string{"pkg"},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	// テストファイルは max_lines_per_test_file と比較する
+	assert.Equal(t, Result{Path: "pkg/server.go", Type: TypeFile, Lines: 320, Limit: 300, Threshold: 330,
+		Severity: SeverityWarn}, report.Results[0])
+	assert.Equal(t, Result{Path: "pkg/server_test.go", Type: TypeFile, Lines: 500, Limit: 600, Threshold: 660,
+		Severity: SeverityPass, Test: true}, report.Results[1])
+	assert.Equal(t, SeverityError, report.Results[2].Severity)
+	// ディレクトリの合計にはテストファイルを含む
+	assert.Equal(t, 1520, report.Results[3].Lines)
+
+	assert.Equal(t, 2, report.TestFiles)
+	assert.Equal(t, 1200, report.TestLines)
+	assert.Equal(t, 1, report.ProductionFiles)
+	assert.Equal(t, 320, report.ProductionLines)
+	assert.InDelta(t, 3.75, report.TestRatio(), 0.001)
+
+	// exclude_tests_from_directory の場合はディレクトリの合計からテストファイルを除く
+	cfg.Rules.ExcludeTestsFromDirectory = true
+	report = Analyze(counts, scanResult, cfg)
+	assert.Equal(t, TypeDirectory, report.Results[3].Type)
+	assert.Equal(t, 320, report.Results[3].Lines)
+
+	// max_lines_per_test_file が 0 の場合は max_lines_per_file を使う
+	cfg.Rules.MaxLinesPerTestFile = 0
+	report = Analyze(counts, scanResult, cfg)
+	assert.Equal(t, 300, report.Results[1].Limit)
+	assert.Equal(t, SeverityError, report.Results[1].Severity)
+
+	// 本番コードがない場合の比は 0
+	assert.Zero(t, (&AnalysisReport{TestLines: 10}).TestRatio())
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter"
+	"github.com/ousiassllc/linterly/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarningBand(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		policy   config.WarningPolicy
+		expected warningBand
+	}{
+		{"grace・割合", 30, config.WarningPolicy{Mode: config.WarningModeGrace, Threshold: 10}, warningBand{warnAbove: 30, errorAbove: 33}},
+		{"grace・行数", 30, config.WarningPolicy{Mode: config.WarningModeGrace, Threshold: 10, MarginLines: 10}, warningBand{warnAbove: 30, errorAbove: 40}},
+		{"early・割合", 5000, config.WarningPolicy{Mode: config.WarningModeEarly, Threshold: 10}, warningBand{warnAbove: 4500, errorAbove: 5000}},
+		{"early・行数", 5000, config.WarningPolicy{Mode: config.WarningModeEarly, MarginLines: 100}, warningBand{warnAbove: 4900, errorAbove: 5000}},
+		{"early・上限より大きい範囲", 30, config.WarningPolicy{Mode: config.WarningModeEarly, MarginLines: 50}, warningBand{warnAbove: 0, errorAbove: 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newWarningBand(tt.limit, tt.policy))
+		})
+	}
+
+	band := warningBand{warnAbove: 90, errorAbove: 100}
+	assert.Equal(t, SeverityPass, band.judge(90))
+	assert.Equal(t, SeverityWarn, band.judge(91))
+	assert.Equal(t, SeverityWarn, band.judge(100))
+	assert.Equal(t, SeverityError, band.judge(101))
+}
+
+func TestAnalyze_WarningPolicyPerRule(t *testing.T) {
+	early := config.WarningModeEarly
+	margin := 50
+	cfg := newTestConfig()
+	cfg.Rules.Warnings = map[string]config.RuleWarning{
+		// ディレクトリは上限の 10% 手前から warn、上限を超えたら error
+		config.RuleMaxLinesPerDirectory: {Mode: &early},
+		// ファイルは上限を超えてから 50 行まで warn
+		config.RuleMaxLinesPerFile: {MarginLines: &margin},
+	}
+
+	counts := []counter.LineCount{
+		{Path: "src/a.go", TotalLines: 340, CodeLines: 340},
+		{Path: "src/b.go", TotalLines: 250, CodeLines: 250},
+		{Path: "src/c.go", TotalLines: 1300, CodeLines: 1300},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "src/a.go", Dir: "src"}, {Path: "src/b.go", Dir: "src"}, {Path: "src/c.go", Dir: "src"}},
+		Dirs:  []string{"src"},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	// 340 行は 300 + 50 行の範囲内
+	assert.Equal(t, Result{Path: "src/a.go", Type: TypeFile, Lines: 340, Limit: 300, Threshold: 350, Severity: SeverityWarn},
+		report.Results[0])
+	// 1890 行は上限 2000 の手前 200 行の範囲内
+	assert.Equal(t, Result{Path: "src/", Type: TypeDirectory, Lines: 1890, Limit: 2000, Threshold: 2000, Severity: SeverityWarn},
+		report.Results[3])
+}
+
+func TestAnalyze_RuleSeverity(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Rules.MaxLineLength = 100
+	cfg.Rules.Severity = map[string]string{
+		config.RuleMaxLinesPerDirectory: config.SeverityInfo,
+		config.RuleMaxLinesPerFile:      config.SeverityWarn,
+		config.RuleMaxLineLength:        config.SeverityOff,
+	}
+
+	counts := []counter.LineCount{
+		{Path: "src/a.go", TotalLines: 1000, CodeLines: 1000, MaxLineWidth: 200},
+		{Path: "src/b.go", TotalLines: 1500, CodeLines: 1500, MaxLineWidth: 200},
+	}
+	scanResult := &scanner.ScanResult{
+		Files: []scanner.FileEntry{{Path: "src/a.go", Dir: "src"}, {Path: "src/b.go", Dir: "src"}},
+		Dirs:  []string{"src"},
+	}
+
+	report := Analyze(counts, scanResult, cfg)
+	// off のルール（max_line_length）は結果を出力しない
+	require.Len(t, report.Results, 3)
+	// warn のルールは error の範囲でも warn
+	assert.Equal(t, SeverityWarn, report.Results[0].Severity)
+	assert.Equal(t, SeverityWarn, report.Results[1].Severity)
+	assert.Equal(t, TypeDirectory, report.Results[2].Type)
+	assert.Equal(t, SeverityInfo, report.Results[2].Severity)
+	assert.Equal(t, 0, report.Errors)
+	assert.Equal(t, 2, report.Warnings)
+	assert.Equal(t, 1, report.Infos)
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -29,19 +30,29 @@ var (
 	flagCountMode            string
 	flagIgnore               []string
 	flagNoDefaultExcludes    bool
+	flagGitTracked           bool
+	flagFailOn               string
+	flagMaxWarnings          int
+
+	// flagFilesFrom は --files-from フラグの値（パスの一覧のファイル。"-" は stdin）を保持する。
+	flagFilesFrom string
+	// flagStrictConfig は --strict-config フラグの値（false の場合は設定ファイルの未知のキー等をエラーにしない）を保持する。
+	flagStrictConfig bool
 )
 
 var checkCmd = &cobra.Command{
-	Use:   "check [path]",
+	Use:   "check [path...]",
 	Short: "Run code line count checks",
 	Long:  "Check source code line counts against configured rules and report violations.",
-	Args:  cobra.MaximumNArgs(1),
+	Args:  cobra.ArbitraryArgs,
 	RunE:  runCheck,
 }
 
 func init() {
 	checkCmd.Flags().StringVarP(&configFile, "config", "c", "", "config file (default is .linterly.yml)")
 	checkCmd.Flags().StringVarP(&format, "format", "f", reporter.FormatText, "output format (text or json)")
+	checkCmd.Flags().BoolVar(&flagStrictConfig, "strict-config", true, "reject unknown keys and type mismatches in config files")
+	checkCmd.Flags().StringVar(&flagFilesFrom, "files-from", "", "read newline- or NUL-separated paths to check from a file (- for stdin)")
 
 	// 設定上書きフラグ
 	checkCmd.Flags().IntVar(&flagMaxLinesPerFile, "max-lines-per-file", config.DefaultMaxLinesPerFile, "max lines per file")
@@ -50,23 +61,41 @@ func init() {
 	checkCmd.Flags().StringVar(&flagCountMode, "count-mode", config.CountModeAll, "count mode (all or code_only)")
 	checkCmd.Flags().StringArrayVar(&flagIgnore, "ignore", nil, "ignore pattern (can be specified multiple times)")
 	checkCmd.Flags().BoolVar(&flagNoDefaultExcludes, "no-default-excludes", false, "disable default excludes")
+	checkCmd.Flags().BoolVar(&flagGitTracked, "git-tracked", false, "check only files tracked by git")
+	checkCmd.Flags().StringVar(&flagFailOn, "fail-on", config.FailOnError, "lowest severity that fails the check (error, warn or info)")
+	checkCmd.Flags().IntVar(&flagMaxWarnings, "max-warnings", -1, "fail when the number of warnings exceeds this value (-1 for no limit)")
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
-	// ターゲットパスの決定
-	targetPath := "."
-	if len(args) > 0 {
-		targetPath = args[0]
-	}
-
 	// config 読み込み前に言語を解決して Translator を初期化
 	translator, lang, err := initTranslator()
 	if err != nil {
 		return err
 	}
 
-	// 設定ファイルの読み込み
-	cfg, err := config.Load(configFile)
+	// ターゲットパスの決定（引数と --files-from の一覧。いずれもなければカレントディレクトリ）
+	// --files-from の一覧のうち存在しないパス（削除されたファイル等）はスキップする
+	targets := args
+	if flagFilesFrom != "" {
+		paths, err := scanner.ReadPathListFile(flagFilesFrom, cmd.InOrStdin())
+		if err != nil {
+			return NewRuntimeError("failed to read --files-from: %v", err)
+		}
+		paths, missing := scanner.SplitMissing(paths)
+		for _, p := range missing {
+			fmt.Fprintln(os.Stderr, translator.T("check.skip_missing", p))
+		}
+		targets = append(append([]string{}, args...), paths...)
+	} else if len(targets) == 0 {
+		targets = []string{"."}
+	}
+
+	// 設定ファイルの読み込み（最初のターゲットから親ディレクトリへ探索する）
+	startPath := "."
+	if len(targets) > 0 {
+		startPath = targets[0]
+	}
+	cfg, err := config.LoadWithOptions(configFile, startPath, config.LoadOptions{Lenient: !flagStrictConfig})
 	if err != nil {
 		return NewRuntimeError("%s", translateConfigError(translator, err))
 	}
@@ -92,24 +121,34 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	// ファイル走査
-	scanResult, err := scanner.Scan(targetPath, cfg)
+	// ネストした設定ファイルの読み込みエラーは設定ファイルのエラーとして出力する
+	scanResult, err := scanner.ScanPaths(targets, cfg)
 	if err != nil {
+		if isConfigError(err) {
+			return NewRuntimeError("%s", translateConfigError(translator, err))
+		}
 		return NewRuntimeError("failed to scan files: %v", err)
 	}
-
-	// ファイルパスを絶対パスに変換（カウント用）
-	absTarget, err := filepath.Abs(targetPath)
-	if err != nil {
-		return NewRuntimeError("failed to resolve path: %v", err)
+	// YAML 以外の形式のため厳密なチェックを行わなかった設定ファイルを警告する
+	for _, p := range cfg.UncheckedFiles() {
+		fmt.Fprintln(os.Stderr, translator.T("check.config_unchecked", p))
 	}
 
+	// ファイルパスを絶対パスに変換（カウント用）
 	filePaths := make([]string, len(scanResult.Files))
 	for i, f := range scanResult.Files {
-		filePaths[i] = filepath.Join(absTarget, f.Path)
+		filePaths[i] = filepath.Join(scanResult.Base, f.Path)
 	}
 
 	// 行数カウント
-	counts, err := counter.CountFiles(filePaths, cfg.CountMode)
+	// ネストした設定ファイルのスコープ内のファイルはそのスコープの設定でカウントする
+	opts := counter.NewOptions(cfg)
+	counts, err := counter.CountFilesFunc(filePaths, func(i int) counter.Options {
+		if c := scanResult.Files[i].Config; c != nil {
+			return counter.NewOptions(c)
+		}
+		return opts
+	})
 	if err != nil {
 		return NewRuntimeError("failed to count lines: %v", err)
 	}
@@ -117,10 +156,12 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	// カウント結果のパスを相対パスに戻す
 	for i := range counts {
 		counts[i].Path = scanResult.Files[i].Path
+		counts[i].Test = scanResult.Files[i].Test
 	}
 
 	// ルール評価
 	report := analyzer.Analyze(counts, scanResult, cfg)
+	report.ApplyExitPolicy(cfg.FailOn, cfg.MaxWarnings)
 
 	// 結果出力
 	rep := reporter.NewReporter(format, translator, os.Stdout)
@@ -129,7 +170,7 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	// 終了コード
-	if report.Errors > 0 {
+	if report.Failure != nil {
 		return NewViolationError()
 	}
 
@@ -160,6 +201,15 @@ func buildOverrides(cmd *cobra.Command) *config.Overrides {
 	if flags.Changed("no-default-excludes") {
 		o.NoDefaultExcludes = flagNoDefaultExcludes
 	}
+	if flags.Changed("git-tracked") {
+		o.GitTracked = flagGitTracked
+	}
+	if flags.Changed("fail-on") {
+		o.FailOn = &flagFailOn
+	}
+	if flags.Changed("max-warnings") {
+		o.MaxWarnings = &flagMaxWarnings
+	}
 
 	return o
 }
@@ -181,19 +231,35 @@ func translateConfigError(tr *i18n.Translator, err error) string {
 	if errors.As(err, &valErrs) {
 		msgs := make([]string, len(valErrs.Errors))
 		for i, e := range valErrs.Errors {
-			msgs[i] = tr.T(e.Code)
+			msgs[i] = translateOne(tr, e)
 		}
 		return strings.Join(msgs, "; ")
 	}
 
 	var cfgErr *config.ConfigError
 	if errors.As(err, &cfgErr) {
-		if cfgErr.Detail != "" {
-			return tr.T(cfgErr.Code, cfgErr.Detail)
-		}
-		return tr.T(cfgErr.Code)
+		return translateOne(tr, cfgErr)
 	}
 
 	// ConfigError でない場合はそのまま返す
 	return err.Error()
 }
+
+// isConfigError は err が設定ファイルのエラー（ConfigError・ValidationErrors）かを返す。
+func isConfigError(err error) bool {
+	var valErrs *config.ValidationErrors
+	var cfgErr *config.ConfigError
+	return errors.As(err, &valErrs) || errors.As(err, &cfgErr)
+}
+
+// translateOne は ConfigError を i18n メッセージに変換し、位置が分かる場合は "file:line:column: " を前置する。
+func translateOne(tr *i18n.Translator, e *config.ConfigError) string {
+	msg := tr.T(e.Code)
+	if e.Detail != "" {
+		msg = tr.T(e.Code, e.Detail)
+	}
+	if loc := e.Location(); loc != "" {
+		return loc + ": " + msg
+	}
+	return msg
+}
@@ -38,6 +38,54 @@ func TestRunCheck_ValidationError_Japanese(t *testing.T) {
 	assert.Contains(t, exitErr.Message, "正の整数")
 }
 
+func TestRunCheck_NestedConfigError_Japanese(t *testing.T) {
+	old := configFile
+	oldLang := langFlag
+	defer func() {
+		configFile = old
+		langFlag = oldLang
+	}()
+
+	tmpDir := t.TempDir()
+	cfgPath := filepath.Join(tmpDir, ".linterly.yml")
+	helperWriteFile(t, cfgPath, "rules:\n  max_lines_per_file: 300\n")
+	nestedPath := filepath.Join(tmpDir, "sub", ".linterly.yml")
+	helperWriteFile(t, nestedPath, "ignore:\n  - \"*.tmp\"\n")
+	helperWriteFile(t, filepath.Join(tmpDir, "sub", "a.go"), "package sub\n")
+	configFile = cfgPath
+	langFlag = "ja"
+
+	err := runCheck(checkCmd, []string{tmpDir})
+	require.Error(t, err)
+
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, ExitRuntimeError, exitErr.Code)
+	// ネストした設定ファイルのエラーも位置付きの設定エラーとして出力される
+	assert.Contains(t, exitErr.Message, nestedPath+":2:")
+	assert.Contains(t, exitErr.Message, "ネストした設定ファイルでは指定できません")
+	assert.NotContains(t, exitErr.Message, "failed to scan files")
+}
+
+func TestRunCheck_UncheckedConfigFormat(t *testing.T) {
+	old := configFile
+	defer func() { configFile = old }()
+
+	tmpDir := t.TempDir()
+	cfgPath := filepath.Join(tmpDir, "linterly.toml")
+	helperWriteFile(t, cfgPath, "[rules]\nmax_lines_per_file = 300\n")
+	helperWriteFile(t, filepath.Join(tmpDir, "a.go"), "package a\n")
+	configFile = cfgPath
+
+	// YAML 以外の形式の設定ファイルはチェックせずに読み込み、stderr に警告する
+	stderr := helperCaptureStderr(t, func() {
+		_ = helperCaptureStdout(t, func() {
+			require.NoError(t, runCheck(checkCmd, []string{tmpDir}))
+		})
+	})
+	assert.Contains(t, stderr, cfgPath)
+}
+
 func TestRunCheck_ConfigNotFound_Japanese(t *testing.T) {
 	old := configFile
 	oldLang := langFlag
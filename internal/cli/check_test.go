@@ -199,3 +199,85 @@ default_excludes: false
 	err := runCheck(checkCmd, []string{targetDir})
 	assert.NoError(t, err)
 }
+
+func TestRunCheck_FlagFailOn_Override(t *testing.T) {
+	oldCfg := configFile
+	oldFmt := format
+	oldFailOn := flagFailOn
+	oldMaxWarnings := flagMaxWarnings
+	defer func() {
+		configFile = oldCfg
+		format = oldFmt
+		flagFailOn = oldFailOn
+		flagMaxWarnings = oldMaxWarnings
+	}()
+
+	tmpDir := t.TempDir()
+	cfgPath := filepath.Join(tmpDir, ".linterly.yml")
+	// threshold=100 → 12行のファイルは warn 止まり
+	helperWriteFile(t, cfgPath, "rules:\n  max_lines_per_file: 10\n  max_lines_per_directory: 100000\n  warning_threshold: 100\ndefault_excludes: false\n")
+
+	targetDir := filepath.Join(tmpDir, "src")
+	helperWriteFile(t, filepath.Join(targetDir, "main.go"), strings.Repeat("line\n", 12))
+
+	configFile = cfgPath
+	format = reporter.FormatText
+	flagFailOn = "warn"
+	helperSetFlag(t, "fail-on")
+
+	err := runCheck(checkCmd, []string{targetDir})
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, ExitViolation, exitErr.Code)
+
+	// --max-warnings の上限を超えた場合も終了コード 1
+	checkCmd.Flags().Lookup("fail-on").Changed = false
+	flagMaxWarnings = 0
+	helperSetFlag(t, "max-warnings")
+
+	err = runCheck(checkCmd, []string{targetDir})
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, ExitViolation, exitErr.Code)
+}
+
+func TestRunCheck_FilesFrom(t *testing.T) {
+	oldCfg := configFile
+	oldFmt := format
+	oldFilesFrom := flagFilesFrom
+	defer func() {
+		configFile = oldCfg
+		format = oldFmt
+		flagFilesFrom = oldFilesFrom
+		checkCmd.SetIn(nil)
+	}()
+
+	tmpDir := t.TempDir()
+	cfgPath := filepath.Join(tmpDir, ".linterly.yml")
+	helperWriteFile(t, cfgPath, "rules:\n  max_lines_per_file: 10\n  max_lines_per_directory: 100000\n")
+	okFile := filepath.Join(tmpDir, "ok.go")
+	ngFile := filepath.Join(tmpDir, "ng.go")
+	helperWriteFile(t, okFile, "line\n")
+	helperWriteFile(t, ngFile, strings.Repeat("line\n", 20))
+
+	configFile = cfgPath
+	format = reporter.FormatText
+
+	// 引数のファイルのみチェックする（複数指定可）
+	require.NoError(t, runCheck(checkCmd, []string{okFile, okFile}))
+
+	// --files-from - は stdin の NUL 区切りの一覧を引数に追加する
+	flagFilesFrom = "-"
+	checkCmd.SetIn(strings.NewReader(ngFile + "\x00"))
+	err := runCheck(checkCmd, []string{okFile})
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, ExitViolation, exitErr.Code)
+
+	// 一覧のうち存在しないパス（削除されたファイル）はスキップして stderr に出力する
+	deleted := filepath.Join(tmpDir, "deleted.go")
+	checkCmd.SetIn(strings.NewReader(okFile + "\n" + deleted + "\n"))
+	stderr := helperCaptureStderr(t, func() {
+		require.NoError(t, runCheck(checkCmd, nil))
+	})
+	assert.Contains(t, stderr, deleted)
+}
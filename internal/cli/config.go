@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ousiassllc/linterly/internal/config/schema"
+)
+
+// configCmd は設定ファイルに関するサブコマンド（linterly config ...）の親コマンド。
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Commands for the config file",
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the config file",
+	Long: "Print the JSON Schema of the config file (.linterly.yml) to stdout.\n" +
+		"Use it for completion and validation in editors, e.g. with YAML Language Server.",
+	Args: cobra.NoArgs,
+	RunE: runConfigSchema,
+}
+
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+// runConfigSchema は設定ファイルの JSON Schema を標準出力に書き出す。
+func runConfigSchema(cmd *cobra.Command, args []string) error {
+	data, err := schema.JSON()
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(data)
+	return err
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConfigSchema(t *testing.T) {
+	var out bytes.Buffer
+	configSchemaCmd.SetOut(&out)
+	defer configSchemaCmd.SetOut(nil)
+	require.NoError(t, runConfigSchema(configSchemaCmd, nil))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", got["$schema"])
+	assert.Contains(t, got["properties"], "rules")
+}
+
+func TestConfigSchemaCmd_RejectsArgs(t *testing.T) {
+	assert.Error(t, configSchemaCmd.Args(configSchemaCmd, []string{"extra"}))
+	assert.NoError(t, configSchemaCmd.Args(configSchemaCmd, nil))
+}
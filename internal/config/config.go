@@ -1,20 +1,21 @@
 package config
 
 import (
-	"errors"
 	"fmt"
-	"os"
 	"strings"
 
-	"github.com/spf13/viper"
-
-	"github.com/ousiassllc/linterly/internal/i18n"
+	"github.com/ousiassllc/linterly/internal/config/defaults"
 )
 
 const (
 	CountModeAll      = "all"
 	CountModeCodeOnly = "code_only"
 
+	// 終了コードを 1 にする違反レベル（fail_on の値）
+	FailOnError = "error"
+	FailOnWarn  = "warn"
+	FailOnInfo  = "info"
+
 	// デフォルトルール値
 	DefaultMaxLinesPerFile      = 300
 	DefaultMaxLinesPerDirectory = 2000
@@ -33,12 +34,29 @@ type ConfigError struct {
 	Code    string
 	Message string
 	Detail  string // err.config_parse 用の詳細情報
+	// File・Line・Column はエラーの原因となった設定ファイルと位置（不明な場合は空・0）
+	File         string
+	Line, Column int
 }
 
 func (e *ConfigError) Error() string {
+	if loc := e.Location(); loc != "" {
+		return loc + ": " + e.Message
+	}
 	return e.Message
 }
 
+// Location はエラーの位置を "file:line:column" の形式で返す（行が不明な場合はファイルのみ、列が不明な場合は "file:line"）。
+func (e *ConfigError) Location() string {
+	if e.File == "" || e.Line == 0 {
+		return e.File
+	}
+	if e.Column == 0 {
+		return fmt.Sprintf("%s:%d", e.File, e.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", e.File, e.Line, e.Column)
+}
+
 // ValidationErrors は複数のバリデーションエラーをまとめる。
 type ValidationErrors struct {
 	Errors []*ConfigError
@@ -47,7 +65,7 @@ type ValidationErrors struct {
 func (e *ValidationErrors) Error() string {
 	msgs := make([]string, len(e.Errors))
 	for i, err := range e.Errors {
-		msgs[i] = err.Message
+		msgs[i] = err.Error()
 	}
 	return strings.Join(msgs, "; ")
 }
@@ -66,6 +84,11 @@ count_mode: all
 # default_excludes: true
 # language: en
 # update_check: true
+# skip_generated: false
+# respect_gitattributes: false
+# respect_gitignore: false
+# git_tracked: false
+# report_skipped_binary: false
 `, DefaultMaxLinesPerFile, DefaultMaxLinesPerDirectory, DefaultWarningThreshold)
 
 // Config は設定ファイルの内容を表す。
@@ -76,8 +99,44 @@ type Config struct {
 	DefaultExcludes bool     `yaml:"default_excludes" mapstructure:"default_excludes"`
 	Language        string   `yaml:"language" mapstructure:"language"`
 	UpdateCheck     bool     `yaml:"update_check" mapstructure:"update_check"`
+	// SkipGenerated が true の場合、先頭行が GeneratedPatterns にマッチするファイルを自動生成ファイルとしてスキップする
+	SkipGenerated     bool     `yaml:"skip_generated" mapstructure:"skip_generated"`
+	GeneratedPatterns []string `yaml:"generated_patterns" mapstructure:"generated_patterns"`
+	// RespectGitattributes が true の場合、.gitattributes で linguist-generated / linguist-vendored
+	// が指定されたファイルを除外する
+	RespectGitattributes bool `yaml:"respect_gitattributes" mapstructure:"respect_gitattributes"`
+	// RespectGitignore が true の場合、.gitignore（ルート・サブディレクトリ）、.git/info/exclude、
+	// Git の core.excludesFile のパターンでも除外する
+	RespectGitignore bool `yaml:"respect_gitignore" mapstructure:"respect_gitignore"`
+	// GitTracked が true の場合、ディレクトリを走査せず Git のインデックスに登録されたファイルのみをチェックする
+	GitTracked bool `yaml:"git_tracked" mapstructure:"git_tracked"`
+	// ReportSkippedBinary が true の場合、バイナリとしてスキップしたファイルを結果に出力する（誤判定の確認用）
+	ReportSkippedBinary bool `yaml:"report_skipped_binary" mapstructure:"report_skipped_binary"`
+	// TestPatterns はテストファイルを判定するパターン（gitignore 形式）
+	TestPatterns []string `yaml:"test_patterns" mapstructure:"test_patterns"`
+	// FailOn は終了コードを 1 にする最低の違反レベル（error / warn / info）
+	FailOn string `yaml:"fail_on" mapstructure:"fail_on"`
+	// MaxWarnings は許容する warn の件数。超えた場合は終了コードを 1 にする（負の値は無制限）
+	MaxWarnings int `yaml:"max_warnings" mapstructure:"max_warnings"`
+	// Budgets はパターンにマッチするファイルの合計行数の上限
+	Budgets []Budget `yaml:"budgets" mapstructure:"budgets"`
+	// Extends は継承元の設定ファイルのパス（設定ファイルからの相対パス）または同梱プリセット名（"linterly:strict" 等）
+	Extends []string `yaml:"extends" mapstructure:"extends"`
+	// Root が true の場合、親ディレクトリの設定ファイルを継承しない（ネストした設定ファイルのスコープの起点にする）
+	Root bool `yaml:"root" mapstructure:"root"`
+
+	// ProjectRoot は除外パターン等の基準となるプロジェクトルート（絶対パス）。設定ファイルを自動探索で
+	// 見つけた場合はそのディレクトリ。空の場合はカレントディレクトリ
+	ProjectRoot string `yaml:"-" mapstructure:"-"`
 
 	ignoreCache *ignoreCacheEntry
+	// lenient は未知のキー・型の不一致を検出しないかどうか（LoadOptions.Lenient。ネストした設定ファイルにも適用する）
+	lenient bool
+	// overrides は ApplyOverrides で適用した上書き（ネストした設定ファイルのスコープにも適用する）
+	overrides *Overrides
+	// unchecked は YAML として解析できない形式のため、未知のキー・型の不一致を検出せずに読み込んだ
+	// 設定ファイル（ネストした設定ファイルのスコープと共有する）
+	unchecked *[]string
 }
 
 type ignoreCacheEntry struct {
@@ -91,6 +150,34 @@ type Rules struct {
 	MaxLinesPerFile      int `yaml:"max_lines_per_file" mapstructure:"max_lines_per_file"`
 	MaxLinesPerDirectory int `yaml:"max_lines_per_directory" mapstructure:"max_lines_per_directory"`
 	WarningThreshold     int `yaml:"warning_threshold" mapstructure:"warning_threshold"`
+	// 行数で指定する warn の範囲（0 より大きい場合は warning_threshold より優先する）
+	WarningMarginLines int `yaml:"warning_margin_lines" mapstructure:"warning_margin_lines"`
+	// warn の範囲を上限の後ろ（grace）・手前（early）のどちらに置くか
+	WarningMode string `yaml:"warning_mode" mapstructure:"warning_mode"`
+	// ルールごとの警告の設定の上書き（キーはルール名）
+	Warnings map[string]RuleWarning `yaml:"warnings" mapstructure:"warnings"`
+	// ルールごとの違反レベル（error / warn / info / off、キーはルール名）
+	Severity map[string]string `yaml:"severity" mapstructure:"severity"`
+	// Vue/Svelte/Astro の script・template セクションごとの上限（0 の場合はチェックしない）
+	MaxScriptLinesPerComponent   int `yaml:"max_script_lines_per_component" mapstructure:"max_script_lines_per_component"`
+	MaxTemplateLinesPerComponent int `yaml:"max_template_lines_per_component" mapstructure:"max_template_lines_per_component"`
+	// 1行あたりの最大表示幅（0 の場合はチェックしない）
+	MaxLineLength int `yaml:"max_line_length" mapstructure:"max_line_length"`
+	// 1関数あたりの最大行数（0 の場合はチェックしない）
+	MaxLinesPerFunction int `yaml:"max_lines_per_function" mapstructure:"max_lines_per_function"`
+	// 1ファイルあたりのトップレベルの宣言（関数・型）の最大数（0 の場合はチェックしない）
+	MaxDeclarationsPerFile int `yaml:"max_declarations_per_file" mapstructure:"max_declarations_per_file"`
+	// テストファイルの最大行数（0 の場合は max_lines_per_file を使う）
+	MaxLinesPerTestFile int `yaml:"max_lines_per_test_file" mapstructure:"max_lines_per_test_file"`
+	// true の場合、ディレクトリの合計行数にテストファイルを含めない
+	ExcludeTestsFromDirectory bool `yaml:"exclude_tests_from_directory" mapstructure:"exclude_tests_from_directory"`
+}
+
+// Budget はパターンにマッチするファイル全体の行数の上限（予算）。
+// Path はプロジェクトルートからの相対パスのパターンで、"**" は0個以上の階層にマッチする。
+type Budget struct {
+	Path     string `yaml:"path" mapstructure:"path"`
+	MaxLines int    `yaml:"max_lines" mapstructure:"max_lines"`
 }
 
 // Overrides は CLI フラグによる設定上書きを表す。
@@ -102,6 +189,9 @@ type Overrides struct {
 	CountMode            *string
 	Ignore               []string // nil=未指定, non-nil=上書き
 	NoDefaultExcludes    bool     // true の場合 DefaultExcludes を false にする
+	GitTracked           bool     // true の場合 GitTracked を true にする
+	FailOn               *string
+	MaxWarnings          *int
 }
 
 // ApplyOverrides は Overrides の非 nil フィールドで Config を上書きし、
@@ -110,6 +200,7 @@ func (c *Config) ApplyOverrides(o *Overrides) error {
 	if o == nil {
 		return validate(c)
 	}
+	c.overrides = o
 	if o.MaxLinesPerFile != nil {
 		c.Rules.MaxLinesPerFile = *o.MaxLinesPerFile
 	}
@@ -129,9 +220,23 @@ func (c *Config) ApplyOverrides(o *Overrides) error {
 	if o.NoDefaultExcludes {
 		c.DefaultExcludes = false
 	}
+	if o.GitTracked {
+		c.GitTracked = true
+	}
+	if o.FailOn != nil {
+		c.FailOn = *o.FailOn
+	}
+	if o.MaxWarnings != nil {
+		c.MaxWarnings = *o.MaxWarnings
+	}
 	return validate(c)
 }
 
+// Default は設定ファイルなしで動作する際のデフォルト Config を返す（JSON Schema のデフォルト値等に使う）。
+func Default() *Config {
+	return defaultConfig()
+}
+
 // defaultConfig は設定ファイルなしで動作する際のデフォルト Config を返す。
 func defaultConfig() *Config {
 	return &Config{
@@ -139,159 +244,16 @@ func defaultConfig() *Config {
 			MaxLinesPerFile:      DefaultMaxLinesPerFile,
 			MaxLinesPerDirectory: DefaultMaxLinesPerDirectory,
 			WarningThreshold:     DefaultWarningThreshold,
+			WarningMode:          WarningModeGrace,
 		},
-		CountMode:       CountModeAll,
-		Ignore:          []string{},
-		DefaultExcludes: true,
-		Language:        "en",
-		UpdateCheck:     true,
-	}
-}
-
-// Load は設定ファイルを読み込み、バリデーション済みの Config を返す。
-// configPath が空でない場合はそのパスのみを読み込む。
-// 空の場合は探索順序に従って設定ファイルを探す。
-func Load(configPath string) (*Config, error) {
-	v := viper.New()
-
-	explicit, err := findAndReadConfig(v, configPath)
-	if err != nil {
-		// 明示指定のパスが見つからない場合はエラー
-		if explicit {
-			return nil, err
-		}
-		// 自動探索で見つからない場合はデフォルト Config を返す
-		var cfgErr *ConfigError
-		if errors.As(err, &cfgErr) && cfgErr.Code == "err.config_not_found" {
-			return defaultConfig(), nil
-		}
-		return nil, err
-	}
-
-	// --- 設定ファイルが見つかった場合の既存ロジック ---
-	// rules セクションの存在チェック
-	if !v.IsSet("rules") {
-		return nil, &ConfigError{
-			Code:    "validation.rules_required",
-			Message: `"rules" section is required`,
-		}
-	}
-
-	// デフォルト値の設定
-	v.SetDefault("rules.max_lines_per_file", DefaultMaxLinesPerFile)
-	v.SetDefault("rules.max_lines_per_directory", DefaultMaxLinesPerDirectory)
-	v.SetDefault("rules.warning_threshold", DefaultWarningThreshold)
-	v.SetDefault("count_mode", CountModeAll)
-	v.SetDefault("ignore", []string{})
-	v.SetDefault("default_excludes", true)
-	v.SetDefault("language", "en")
-	v.SetDefault("update_check", true)
-
-	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
-		return nil, &ConfigError{
-			Code:    "err.config_parse",
-			Message: fmt.Sprintf("failed to parse config file: %s", err),
-			Detail:  err.Error(),
-		}
-	}
-
-	if err := validate(&cfg); err != nil {
-		return nil, err
-	}
-
-	return &cfg, nil
-}
-
-// wrapViperError は viper の ReadInConfig エラーを ConfigError にラップする。
-func wrapViperError(err error) *ConfigError {
-	var notFoundErr viper.ConfigFileNotFoundError
-	if os.IsNotExist(err) || errors.As(err, &notFoundErr) {
-		return &ConfigError{
-			Code:    "err.config_not_found",
-			Message: err.Error(),
-		}
-	}
-	return &ConfigError{
-		Code:    "err.config_parse",
-		Message: err.Error(),
-	}
-}
-
-// findAndReadConfig は探索順序に従って設定ファイルを見つけて読み込む。
-// explicit は、ユーザーが明示的にパスを指定したかどうかを示す。
-func findAndReadConfig(v *viper.Viper, configPath string) (explicit bool, err error) {
-	if configPath != "" {
-		v.SetConfigFile(configPath)
-		if err := v.ReadInConfig(); err != nil {
-			return true, wrapViperError(err)
-		}
-		return true, nil
-	}
-
-	// LINTERLY_CONFIG 環境変数
-	if envPath := os.Getenv("LINTERLY_CONFIG"); envPath != "" {
-		v.SetConfigFile(envPath)
-		if err := v.ReadInConfig(); err != nil {
-			return true, wrapViperError(err)
-		}
-		return true, nil
-	}
-
-	// カレントディレクトリの .linterly.yml / .linterly.yaml
-	for _, name := range DefaultConfigFileNames {
-		if _, err := os.Stat(name); err == nil {
-			v.SetConfigFile(name)
-			if err := v.ReadInConfig(); err != nil {
-				return false, wrapViperError(err)
-			}
-			return false, nil
-		}
-	}
-
-	return false, &ConfigError{
-		Code:    "err.config_not_found",
-		Message: "config file not found",
-	}
-}
-
-// validate は Config の各フィールドをバリデーションする。
-func validate(cfg *Config) error {
-	var errs []*ConfigError
-
-	if cfg.Rules.MaxLinesPerFile <= 0 {
-		errs = append(errs, &ConfigError{
-			Code:    "validation.max_lines_per_file",
-			Message: `"max_lines_per_file" must be a positive integer`,
-		})
-	}
-	if cfg.Rules.MaxLinesPerDirectory <= 0 {
-		errs = append(errs, &ConfigError{
-			Code:    "validation.max_lines_per_directory",
-			Message: `"max_lines_per_directory" must be a positive integer`,
-		})
-	}
-	if cfg.Rules.WarningThreshold < 0 || cfg.Rules.WarningThreshold > 100 {
-		errs = append(errs, &ConfigError{
-			Code:    "validation.warning_threshold",
-			Message: `"warning_threshold" must be between 0 and 100`,
-		})
-	}
-	if cfg.CountMode != CountModeAll && cfg.CountMode != CountModeCodeOnly {
-		errs = append(errs, &ConfigError{
-			Code:    "validation.count_mode",
-			Message: `"count_mode" must be "all" or "code_only"`,
-		})
-	}
-	if !i18n.IsSupportedLanguage(cfg.Language) {
-		errs = append(errs, &ConfigError{
-			Code:    "validation.language",
-			Message: `"language" must be "en" or "ja"`,
-		})
-	}
-
-	if len(errs) > 0 {
-		return &ValidationErrors{Errors: errs}
+		CountMode:         CountModeAll,
+		Ignore:            []string{},
+		DefaultExcludes:   true,
+		Language:          "en",
+		UpdateCheck:       true,
+		GeneratedPatterns: defaults.GeneratedPatterns(),
+		TestPatterns:      defaults.TestPatterns(),
+		FailOn:            FailOnError,
+		MaxWarnings:       -1,
 	}
-	return nil
 }
@@ -127,3 +127,45 @@ func TestApplyOverrides_IgnoreOverride(t *testing.T) {
 	assert.Empty(t, cfg.Ignore)
 	assert.NotNil(t, cfg.Ignore)
 }
+
+func TestLoad_FailOn(t *testing.T) {
+	cfg, err := Load("testdata/valid_fail_on.yml")
+	require.NoError(t, err)
+	assert.Equal(t, FailOnWarn, cfg.FailOn)
+	assert.Equal(t, 10, cfg.MaxWarnings)
+
+	// 未指定の場合は error で、warn の件数に上限なし
+	cfg, err = Load("testdata/valid_minimal.yml")
+	require.NoError(t, err)
+	assert.Equal(t, FailOnError, cfg.FailOn)
+	assert.Equal(t, -1, cfg.MaxWarnings)
+
+	_, err = Load("testdata/invalid_fail_on.yml")
+	require.Error(t, err)
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	require.Len(t, valErrs.Errors, 1)
+	assert.Equal(t, "validation.fail_on", valErrs.Errors[0].Code)
+}
+
+func TestApplyOverrides_FailOn(t *testing.T) {
+	cfg := defaultConfig()
+	failOn := FailOnInfo
+	maxWarnings := 0
+	require.NoError(t, cfg.ApplyOverrides(&Overrides{FailOn: &failOn, MaxWarnings: &maxWarnings}))
+	assert.Equal(t, FailOnInfo, cfg.FailOn)
+	assert.Equal(t, 0, cfg.MaxWarnings)
+
+	invalid := "fatal"
+	err := cfg.ApplyOverrides(&Overrides{FailOn: &invalid})
+	require.Error(t, err)
+}
+
+func TestApplyOverrides_GitTracked(t *testing.T) {
+	cfg := defaultConfig()
+	require.NoError(t, cfg.ApplyOverrides(&Overrides{}))
+	assert.False(t, cfg.GitTracked)
+
+	require.NoError(t, cfg.ApplyOverrides(&Overrides{GitTracked: true}))
+	assert.True(t, cfg.GitTracked)
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_ComponentLimits(t *testing.T) {
+	cfg, err := Load("testdata/valid_component_limits.yml")
+	require.NoError(t, err)
+
+	assert.Equal(t, 150, cfg.Rules.MaxScriptLinesPerComponent)
+	assert.Equal(t, 80, cfg.Rules.MaxTemplateLinesPerComponent)
+}
+
+func TestLoad_ComponentLimitsDefaultDisabled(t *testing.T) {
+	cfg, err := Load("testdata/valid_minimal.yml")
+	require.NoError(t, err)
+
+	// 未指定の場合は 0（チェックしない）
+	assert.Equal(t, 0, cfg.Rules.MaxScriptLinesPerComponent)
+	assert.Equal(t, 0, cfg.Rules.MaxTemplateLinesPerComponent)
+}
+
+func TestLoad_InvalidComponentLimits(t *testing.T) {
+	_, err := Load("testdata/invalid_component_limits.yml")
+	require.Error(t, err)
+
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	codes := codeList(valErrs)
+	assert.Contains(t, codes, "validation.max_script_lines_per_component")
+	assert.Contains(t, codes, "validation.max_template_lines_per_component")
+}
+
+func TestLoad_SkipGenerated(t *testing.T) {
+	cfg, err := Load("testdata/valid_skip_generated.yml")
+	require.NoError(t, err)
+
+	assert.True(t, cfg.SkipGenerated)
+	assert.Equal(t, []string{`^// Code generated .* DO NOT EDIT\.$`, "^# autogen:"}, cfg.GeneratedPatterns)
+}
+
+func TestLoad_SkipGeneratedDefault(t *testing.T) {
+	cfg, err := Load("testdata/valid_minimal.yml")
+	require.NoError(t, err)
+
+	// 未指定の場合は無効で、パターンはデフォルト一覧
+	assert.False(t, cfg.SkipGenerated)
+	assert.Equal(t, defaults.GeneratedPatterns(), cfg.GeneratedPatterns)
+}
+
+func TestLoad_InvalidGeneratedPatterns(t *testing.T) {
+	_, err := Load("testdata/invalid_generated_patterns.yml")
+	require.Error(t, err)
+
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	require.Len(t, valErrs.Errors, 1)
+	assert.Equal(t, "validation.generated_patterns", valErrs.Errors[0].Code)
+	assert.Equal(t, "(unclosed", valErrs.Errors[0].Detail)
+}
+
+func TestLoad_MaxLineLength(t *testing.T) {
+	cfg, err := Load("testdata/valid_max_line_length.yml")
+	require.NoError(t, err)
+	assert.Equal(t, 120, cfg.Rules.MaxLineLength)
+
+	// 未指定の場合は 0（チェックしない）
+	cfg, err = Load("testdata/valid_minimal.yml")
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.Rules.MaxLineLength)
+}
+
+func TestLoad_InvalidMaxLineLength(t *testing.T) {
+	_, err := Load("testdata/invalid_max_line_length.yml")
+	require.Error(t, err)
+
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	assert.Equal(t, []string{"validation.max_line_length"}, codeList(valErrs))
+}
+
+func TestLoad_FunctionRules(t *testing.T) {
+	cfg, err := Load("testdata/valid_max_lines_per_function.yml")
+	require.NoError(t, err)
+	assert.Equal(t, 60, cfg.Rules.MaxLinesPerFunction)
+	assert.Equal(t, 10, cfg.Rules.MaxDeclarationsPerFile)
+
+	// 未指定の場合は 0（チェックしない）
+	cfg, err = Load("testdata/valid_minimal.yml")
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.Rules.MaxLinesPerFunction)
+	assert.Equal(t, 0, cfg.Rules.MaxDeclarationsPerFile)
+
+	_, err = Load("testdata/invalid_max_lines_per_function.yml")
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	assert.Equal(t, []string{"validation.max_lines_per_function", "validation.max_declarations_per_file"}, codeList(valErrs))
+}
+
+func TestLoad_Budgets(t *testing.T) {
+	cfg, err := Load("testdata/valid_budgets.yml")
+	require.NoError(t, err)
+	assert.Equal(t, []Budget{
+		{Path: "services/billing/**", MaxLines: 25000},
+		{Path: "**", MaxLines: 500000},
+	}, cfg.Budgets)
+
+	_, err = Load("testdata/invalid_budgets.yml")
+	require.Error(t, err)
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	require.Len(t, valErrs.Errors, 3)
+	assert.Equal(t, "validation.budgets", valErrs.Errors[0].Code)
+	assert.Equal(t, []string{`"src/**"`, `""`, `"lib/[a-"`},
+		[]string{valErrs.Errors[0].Detail, valErrs.Errors[1].Detail, valErrs.Errors[2].Detail})
+}
+
+func TestLoad_TestPatterns(t *testing.T) {
+	cfg, err := Load("testdata/valid_test_patterns.yml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*_test.go", "e2e/"}, cfg.TestPatterns)
+	assert.Equal(t, 600, cfg.Rules.MaxLinesPerTestFile)
+	assert.True(t, cfg.Rules.ExcludeTestsFromDirectory)
+
+	// 未指定の場合はデフォルトのパターンで、テストファイルにも max_lines_per_file を使う
+	cfg, err = Load("testdata/valid_minimal.yml")
+	require.NoError(t, err)
+	assert.Equal(t, defaults.TestPatterns(), cfg.TestPatterns)
+	assert.Equal(t, 0, cfg.Rules.MaxLinesPerTestFile)
+	assert.False(t, cfg.Rules.ExcludeTestsFromDirectory)
+
+	_, err = Load("testdata/invalid_max_lines_per_test_file.yml")
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	assert.Equal(t, []string{"validation.max_lines_per_test_file"}, codeList(valErrs))
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"os"
+)
+
+func TestLoad_Strict(t *testing.T) {
+	path := filepath.Join("testdata", "invalid_unknown_keys.yml")
+	_, err := Load(path)
+	require.Error(t, err)
+
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	assert.Equal(t, []string{"validation.unknown_key", "validation.type_integer", "validation.unknown_key"}, codeList(valErrs))
+	assert.Equal(t, "rules.max_line_per_file", valErrs.Errors[0].Detail)
+	assert.Equal(t, path+":2:3", valErrs.Errors[0].Location())
+	assert.Equal(t, path+":3:28", valErrs.Errors[1].Location())
+	assert.Equal(t, path+":5:1", valErrs.Errors[2].Location())
+	assert.Contains(t, err.Error(), path+`:5:1: unknown key "update_checks"`)
+
+	// Lenient の場合は未知のキー・型の不一致を無視する
+	cfg, err := LoadWithOptions(path, ".", LoadOptions{Lenient: true})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMaxLinesPerFile, cfg.Rules.MaxLinesPerFile)
+	assert.Equal(t, 2000, cfg.Rules.MaxLinesPerDirectory)
+}
+
+func TestLoad_ValidationErrorLocation(t *testing.T) {
+	path := filepath.Join("testdata", "invalid_multiple.yml")
+	_, err := Load(path)
+
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	locations := make(map[string]string)
+	for _, e := range valErrs.Errors {
+		locations[e.Code] = e.Location()
+	}
+	assert.Equal(t, path+":2:23", locations["validation.max_lines_per_file"])
+	assert.Equal(t, path+":6:13", locations["validation.count_mode"])
+	assert.Equal(t, path+":7:11", locations["validation.language"])
+}
+
+func TestLoad_StrictFormat(t *testing.T) {
+	dir := t.TempDir()
+	tomlPath := filepath.Join(dir, "linterly.toml")
+	require.NoError(t, os.WriteFile(tomlPath, []byte("[rules]\nmax_lines_per_file = 250\n"), 0644))
+
+	// YAML・JSON 以外の形式はチェックせずに読み込み、UncheckedFiles で警告できるようにする
+	cfg, err := Load(tomlPath)
+	require.NoError(t, err)
+	assert.Equal(t, 250, cfg.Rules.MaxLinesPerFile)
+	assert.Equal(t, []string{tomlPath}, cfg.UncheckedFiles())
+
+	// 継承元の設定ファイルも同様に扱う
+	basePath := filepath.Join(dir, "base.yml")
+	require.NoError(t, os.WriteFile(basePath, []byte("extends: linterly.toml\n"), 0644))
+	cfg, err = Load(basePath)
+	require.NoError(t, err)
+	assert.Equal(t, 250, cfg.Rules.MaxLinesPerFile)
+	assert.Equal(t, []string{tomlPath}, cfg.UncheckedFiles())
+
+	// Lenient の場合は警告の対象にしない
+	cfg, err = LoadWithOptions(tomlPath, ".", LoadOptions{Lenient: true})
+	require.NoError(t, err)
+	assert.Equal(t, 250, cfg.Rules.MaxLinesPerFile)
+	assert.Empty(t, cfg.UncheckedFiles())
+
+	// JSON は YAML として位置付きでチェックする
+	jsonPath := filepath.Join(dir, "linterly.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte("{\n  \"rules\": {\n    \"max_line_per_file\": 250\n  }\n}\n"), 0644))
+	_, err = Load(jsonPath)
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	assert.Equal(t, jsonPath+":3:5", valErrs.Errors[0].Location())
+}
+
+func TestCheckFile_SyntaxError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".linterly.yml")
+	require.NoError(t, os.WriteFile(path, []byte("rules: {}\ncount_mode: all: x\n"), 0644))
+
+	// YAML として解析できない場合は行番号付きの解析エラーを返す
+	_, err := checkFile(path, false, nil)
+	var cfgErr *ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.Equal(t, "err.config_parse", cfgErr.Code)
+	assert.Equal(t, path+":2", cfgErr.Location())
+
+	doc, err := checkFile(path, true, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, doc)
+}
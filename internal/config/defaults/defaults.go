@@ -0,0 +1,105 @@
+// Package defaults は設定のデフォルト値のうち、パターン一覧（除外・自動生成ファイル・テストファイル）を定義する。
+package defaults
+
+// GeneratedPatterns は自動生成ファイルを判定するデフォルトの正規表現一覧を返す。
+// skip_generated: true の場合に、ファイル先頭の各行に対して scanner で使用される。
+func GeneratedPatterns() []string {
+	return []string{
+		// Go の公式規約（https://go.dev/s/generatedcode）
+		`^// Code generated .* DO NOT EDIT\.$`,
+		// Facebook 由来の @generated マーカー（Buck, Relay, Thrift 等）
+		`@generated\b`,
+		// コメント行頭の "This file was automatically generated" / "Auto-generated by ..." /
+		// C# の "<auto-generated>" 等
+		`(?i)^\W*(this (file|code) (is|was) )?(auto-?generated|automatically generated)\b`,
+		// "Generated by ... DO NOT EDIT" / "DO NOT MODIFY" 等
+		`(?i)\bgenerated\b.*\bdo not (edit|modify)\b`,
+	}
+}
+
+// TestPatterns はテストファイルを判定するデフォルトのパターン一覧（gitignore 形式）を返す。
+// ディレクトリのパターンにマッチした場合は配下のファイルをすべてテストとみなす。
+func TestPatterns() []string {
+	return []string{
+		// ディレクトリ
+		"__tests__/",
+		"tests/",
+
+		// Go
+		"*_test.go",
+
+		// JavaScript/TypeScript
+		"*.test.*",
+		"*.spec.*",
+
+		// Python
+		"test_*.py",
+		"*_test.py",
+
+		// Ruby
+		"*_spec.rb",
+		"*_test.rb",
+
+		// Java/Kotlin/C#
+		"*Test.java",
+		"*Tests.java",
+		"*Test.kt",
+		"*Tests.cs",
+	}
+}
+
+// ExcludePatterns はデフォルト除外パターン一覧を返す。
+// default_excludes: true の場合に scanner で使用される。
+func ExcludePatterns() []string {
+	return []string{
+		// 共通
+		".git/",
+		"dist/",
+		"build/",
+		"out/",
+		"*.min.js",
+		"*.min.css",
+		"*.lock",
+		"*-lock.*",
+		"*.gen.*",
+		"*.generated.*",
+		".idea/",
+		".vscode/",
+		".claude/",
+		".cursor/",
+		".gemini/",
+		".cache/",
+
+		// JavaScript/TypeScript
+		"node_modules/",
+		"bower_components/",
+		".next/",
+		".nuxt/",
+		".svelte-kit/",
+		".angular/",
+		".turbo/",
+		".parcel-cache/",
+		".vite/",
+		"coverage/",
+
+		// Go
+		"vendor/",
+		"ent/",
+
+		// Rust
+		"target/",
+
+		// Python
+		"__pycache__/",
+		"*.pyc",
+		"*.pyo",
+		".venv/",
+		"venv/",
+		"env/",
+		"*.egg-info/",
+		".eggs/",
+		".mypy_cache/",
+		".pytest_cache/",
+		".tox/",
+	}
+}
@@ -1,4 +1,4 @@
-package config
+package defaults
 
 import (
 	"testing"
@@ -7,12 +7,12 @@ import (
 )
 
 func TestDefaultExcludePatterns_NotEmpty(t *testing.T) {
-	patterns := DefaultExcludePatterns()
+	patterns := ExcludePatterns()
 	assert.NotEmpty(t, patterns)
 }
 
 func TestDefaultExcludePatterns_ContainsCommonPatterns(t *testing.T) {
-	patterns := DefaultExcludePatterns()
+	patterns := ExcludePatterns()
 
 	common := []string{
 		".git/",
@@ -38,7 +38,7 @@ func TestDefaultExcludePatterns_ContainsCommonPatterns(t *testing.T) {
 }
 
 func TestDefaultExcludePatterns_ContainsJavaScriptPatterns(t *testing.T) {
-	patterns := DefaultExcludePatterns()
+	patterns := ExcludePatterns()
 
 	jsPatterns := []string{
 		"node_modules/",
@@ -58,7 +58,7 @@ func TestDefaultExcludePatterns_ContainsJavaScriptPatterns(t *testing.T) {
 }
 
 func TestDefaultExcludePatterns_ContainsGoPatterns(t *testing.T) {
-	patterns := DefaultExcludePatterns()
+	patterns := ExcludePatterns()
 
 	goPatterns := []string{
 		"vendor/",
@@ -70,12 +70,12 @@ func TestDefaultExcludePatterns_ContainsGoPatterns(t *testing.T) {
 }
 
 func TestDefaultExcludePatterns_ContainsRustPatterns(t *testing.T) {
-	patterns := DefaultExcludePatterns()
+	patterns := ExcludePatterns()
 	assert.Contains(t, patterns, "target/")
 }
 
 func TestDefaultExcludePatterns_ContainsPythonPatterns(t *testing.T) {
-	patterns := DefaultExcludePatterns()
+	patterns := ExcludePatterns()
 
 	pyPatterns := []string{
 		"__pycache__/",
@@ -96,11 +96,19 @@ func TestDefaultExcludePatterns_ContainsPythonPatterns(t *testing.T) {
 }
 
 func TestDefaultExcludePatterns_ReturnsNewSlice(t *testing.T) {
-	p1 := DefaultExcludePatterns()
-	p2 := DefaultExcludePatterns()
+	p1 := ExcludePatterns()
+	p2 := ExcludePatterns()
 
 	// 別のスライスが返されることを確認（変更が互いに影響しない）
 	assert.Equal(t, p1, p2)
 	p1[0] = "modified"
 	assert.NotEqual(t, p1[0], p2[0])
 }
+
+func TestDefaultTestPatterns_ContainsCommonPatterns(t *testing.T) {
+	patterns := TestPatterns()
+
+	for _, p := range []string{"*_test.go", "*.spec.*", "test_*.py", "__tests__/"} {
+		assert.Contains(t, patterns, p)
+	}
+}
@@ -0,0 +1,246 @@
+// Package extends は設定ファイルの extends（継承元の設定ファイル・同梱プリセット）を解決する。
+package extends
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// PresetPrefix は同梱プリセットを指定する extends の値の接頭辞（例: "linterly:strict"）。
+const PresetPrefix = "linterly:"
+
+// Key は継承元を指定する設定キー。
+const Key = "extends"
+
+//go:embed presets/*.yml
+var presetsFS embed.FS
+
+// Error は継承元の設定の解決に失敗したことを表す。Code は i18n メッセージキーに対応する。
+type Error struct {
+	Code    string
+	Message string
+	Detail  string // メッセージに埋め込む値（継承元のパス・循環の経路等）
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Presets は同梱プリセットの名前（接頭辞付き）を名前順で返す。
+func Presets() []string {
+	entries, _ := fs.ReadDir(presetsFS, "presets")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, PresetPrefix+strings.TrimSuffix(e.Name(), ".yml"))
+	}
+	return names
+}
+
+// Apply は v の設定ファイルの内容に extends で指定した継承元の設定を重ねた viper を返す。
+// extends がない場合は v をそのまま返す。check が nil でない場合は、継承元の設定ファイル（プリセットを除く）を
+// 読み込むたびに呼び出し、エラーを返した場合はそのエラーを返す。
+func Apply(v *viper.Viper, check func(path string) error) (*viper.Viper, error) {
+	if !v.IsSet(Key) {
+		return v, nil
+	}
+	settings, err := Resolve(v.ConfigFileUsed(), v.AllSettings(), check)
+	if err != nil {
+		return nil, err
+	}
+	merged := viper.New()
+	if err := merged.MergeConfigMap(settings); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// Resolve は path の設定ファイルの内容 settings（viper の AllSettings の形式）に、extends で指定した
+// 継承元の設定を重ねた内容を返す。継承元は記述順に重ね、後の継承元・settings 自身の値ほど優先する。
+// マップ（rules・rules.warnings 等）はキーごとに再帰的にマージし、ignore のリストは継承元の後ろに連結する。
+// その他のリスト（test_patterns・generated_patterns 等）と値は継承元の値を置き換える。root は継承しない。
+// extends がない場合は settings をそのまま返す。check は Apply と同じ。
+func Resolve(path string, settings map[string]any, check func(path string) error) (map[string]any, error) {
+	r := &resolver{check: check}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolve(abs, settings)
+}
+
+// resolver は継承元を解決中の設定ファイル・プリセットの経路（循環の検出用）を保持する。
+type resolver struct {
+	stack []string
+	check func(path string) error
+}
+
+func (r *resolver) resolve(name string, settings map[string]any) (map[string]any, error) {
+	if _, ok := settings[Key]; !ok {
+		return settings, nil
+	}
+	bases, err := baseNames(name, settings[Key])
+	if err != nil {
+		return nil, err
+	}
+
+	r.stack = append(r.stack, name)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	merged := map[string]any{}
+	for _, base := range bases {
+		if !strings.HasPrefix(base, PresetPrefix) && !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(name), base)
+		}
+		if slices.Contains(r.stack, base) {
+			return nil, &Error{
+				Code:    "err.extends_cycle",
+				Message: fmt.Sprintf("circular extends: %s", r.chain(base)),
+				Detail:  r.chain(base),
+			}
+		}
+		baseSettings, err := read(base)
+		if err != nil {
+			return nil, err
+		}
+		if r.check != nil && !strings.HasPrefix(base, PresetPrefix) {
+			if err := r.check(base); err != nil {
+				return nil, err
+			}
+		}
+		resolved, err := r.resolve(base, baseSettings)
+		if err != nil {
+			return nil, err
+		}
+		delete(resolved, "root")
+		delete(resolved, Key)
+		merged = merge(merged, resolved)
+	}
+
+	merged = merge(merged, settings)
+	merged[Key] = toAnySlice(bases)
+	return merged, nil
+}
+
+// chain は循環した継承の経路を "a -> b -> a" の形式で返す。
+func (r *resolver) chain(last string) string {
+	return strings.Join(append(slices.Clone(r.stack), last), " -> ")
+}
+
+// baseNames は extends の値（文字列または文字列のリスト）を継承元の名前のリストに変換する。
+func baseNames(name string, value any) ([]string, error) {
+	invalid := &Error{
+		Code:    "err.extends_invalid",
+		Message: fmt.Sprintf("%q must be a string or a list of strings: %s", Key, name),
+		Detail:  name,
+	}
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok || s == "" {
+				return nil, invalid
+			}
+			names = append(names, s)
+		}
+		return names, nil
+	case nil:
+		return nil, nil
+	}
+	return nil, invalid
+}
+
+// read は継承元の設定ファイル・プリセットを読み込む。
+func read(name string) (map[string]any, error) {
+	v := viper.New()
+	if preset, ok := strings.CutPrefix(name, PresetPrefix); ok {
+		data, err := presetsFS.ReadFile("presets/" + preset + ".yml")
+		if err != nil {
+			return nil, &Error{
+				Code:    "err.extends_unknown_preset",
+				Message: fmt.Sprintf("unknown preset: %s (available: %s)", name, strings.Join(Presets(), ", ")),
+				Detail:  name,
+			}
+		}
+		v.SetConfigType("yaml")
+		if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+			return nil, parseError(name, err)
+		}
+		return v.AllSettings(), nil
+	}
+
+	if _, err := os.Stat(name); errors.Is(err, fs.ErrNotExist) {
+		return nil, &Error{
+			Code:    "err.extends_not_found",
+			Message: fmt.Sprintf("extended config file not found: %s", name),
+			Detail:  name,
+		}
+	}
+	v.SetConfigFile(name)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, parseError(name, err)
+	}
+	return v.AllSettings(), nil
+}
+
+func parseError(name string, err error) *Error {
+	detail := fmt.Sprintf("%s: %s", name, err)
+	return &Error{
+		Code:    "err.config_parse",
+		Message: fmt.Sprintf("failed to parse config file: %s", detail),
+		Detail:  detail,
+	}
+}
+
+// appendKeys は継承元のリストの後ろに連結するトップレベルのキー。
+// それ以外のリスト（test_patterns・budgets など）は継承先のリストで置き換える。
+var appendKeys = []string{"ignore"}
+
+// merge は base に over を重ねたマップを返す（base・over は変更しない）。
+// マップはキーごとに再帰的にマージし、appendKeys のリストは連結、それ以外の値は over で置き換える。
+func merge(base, over map[string]any) map[string]any {
+	out := mergeMap(base, over)
+	for _, k := range appendKeys {
+		b, bok := base[k].([]any)
+		o, ook := over[k].([]any)
+		if bok && ook {
+			out[k] = append(slices.Clone(b), o...)
+		}
+	}
+	return out
+}
+
+// mergeMap はマップをキーごとに再帰的にマージし、マップ以外の値は over で置き換える。
+func mergeMap(base, over map[string]any) map[string]any {
+	out := maps.Clone(base)
+	for k, ov := range over {
+		if o, ok := ov.(map[string]any); ok {
+			if b, ok := out[k].(map[string]any); ok {
+				out[k] = mergeMap(b, o)
+				continue
+			}
+		}
+		out[k] = ov
+	}
+	return out
+}
+
+func toAnySlice(s []string) []any {
+	out := make([]any, len(s))
+	for i, v := range s {
+		out[i] = v
+	}
+	return out
+}
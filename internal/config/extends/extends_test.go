@@ -0,0 +1,153 @@
+package extends
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles は dir 以下にファイルを作成する（キーは dir からの相対パス）。
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+}
+
+// apply は設定ファイルを読み込み、Apply の結果を返す。
+func apply(t *testing.T, path string) (*viper.Viper, error) {
+	t.Helper()
+	v := viper.New()
+	v.SetConfigFile(path)
+	require.NoError(t, v.ReadInConfig())
+	return Apply(v, nil)
+}
+
+func TestApply_Merge(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"org/base.yml": "extends: linterly:recommended\nroot: true\n" +
+			"rules:\n  max_lines_per_file: 400\n  severity:\n    max_lines_per_directory: warn\n" +
+			"ignore:\n  - \"vendor/**\"\n" +
+			"test_patterns:\n  - \"**/*_spec.go\"\n  - \"**/*_test.go\"\n",
+		"team.yml": "rules:\n  max_line_length: 100\n",
+		"repo/.linterly.yml": "extends:\n  - ../org/base.yml\n  - ../team.yml\n" +
+			"rules:\n  max_lines_per_file: 250\n  severity:\n    max_lines_per_file: info\n" +
+			"ignore:\n  - \"*.pb.go\"\n" +
+			"test_patterns:\n  - \"**/*_test.go\"\n",
+	})
+
+	v, err := apply(t, filepath.Join(dir, "repo", ".linterly.yml"))
+	require.NoError(t, err)
+
+	// 自身の値が優先し、マップはキーごとにマージされる
+	assert.Equal(t, 250, v.GetInt("rules.max_lines_per_file"))
+	assert.Equal(t, map[string]string{"max_lines_per_directory": "warn", "max_lines_per_file": "info"}, v.GetStringMapString("rules.severity"))
+	// 複数の継承元・継承元の継承元（プリセット）の値も重なる
+	assert.Equal(t, 100, v.GetInt("rules.max_line_length"))
+	assert.Equal(t, 500, v.GetInt("rules.max_lines_per_test_file"))
+	assert.True(t, v.GetBool("skip_generated"))
+	// ignore は継承元の後ろに連結され、それ以外のリストは自身のリストで置き換えられる
+	assert.Equal(t, []string{"vendor/**", "*.pb.go"}, v.GetStringSlice("ignore"))
+	assert.Equal(t, []string{"**/*_test.go"}, v.GetStringSlice("test_patterns"))
+	// root は継承しない
+	assert.False(t, v.IsSet("root"))
+	assert.Equal(t, []string{"../org/base.yml", "../team.yml"}, v.GetStringSlice("extends"))
+}
+
+func TestApply_NoExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{".linterly.yml": "rules:\n  max_lines_per_file: 250\n"})
+
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(dir, ".linterly.yml"))
+	require.NoError(t, v.ReadInConfig())
+	got, err := Apply(v, nil)
+	require.NoError(t, err)
+	assert.Same(t, v, got)
+}
+
+func TestApply_Presets(t *testing.T) {
+	assert.Equal(t, []string{"linterly:recommended", "linterly:strict"}, Presets())
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{".linterly.yml": "extends: linterly:strict\n"})
+	v, err := apply(t, filepath.Join(dir, ".linterly.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, 200, v.GetInt("rules.max_lines_per_file"))
+	assert.Equal(t, "code_only", v.GetString("count_mode"))
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		code   string
+		detail string
+	}{
+		{
+			name:   "継承元のファイルが存在しない",
+			files:  map[string]string{".linterly.yml": "extends: missing.yml\n"},
+			code:   "err.extends_not_found",
+			detail: "missing.yml",
+		},
+		{
+			name:   "存在しないプリセット",
+			files:  map[string]string{".linterly.yml": "extends: linterly:unknown\n"},
+			code:   "err.extends_unknown_preset",
+			detail: "linterly:unknown",
+		},
+		{
+			name: "循環した継承",
+			files: map[string]string{
+				".linterly.yml": "extends: a.yml\n",
+				"a.yml":         "extends: b.yml\n",
+				"b.yml":         "extends: ./a.yml\n",
+			},
+			code:   "err.extends_cycle",
+			detail: "a.yml -> ",
+		},
+		{
+			name:   "自身を継承",
+			files:  map[string]string{".linterly.yml": "extends: .linterly.yml\n"},
+			code:   "err.extends_cycle",
+			detail: ".linterly.yml -> ",
+		},
+		{
+			name:   "文字列以外の値",
+			files:  map[string]string{".linterly.yml": "extends:\n  - 1\n"},
+			code:   "err.extends_invalid",
+			detail: ".linterly.yml",
+		},
+		{
+			name: "継承元の構文エラー",
+			files: map[string]string{
+				".linterly.yml": "extends: broken.yml\n",
+				"broken.yml":    "rules: [\n",
+			},
+			code:   "err.config_parse",
+			detail: "broken.yml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			_, err := apply(t, filepath.Join(dir, ".linterly.yml"))
+			require.Error(t, err)
+			var extErr *Error
+			require.True(t, errors.As(err, &extErr))
+			assert.Equal(t, tt.code, extErr.Code)
+			assert.Contains(t, extErr.Detail, tt.detail)
+		})
+	}
+}
@@ -3,13 +3,15 @@ package config
 import (
 	"bufio"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
-const linterlyIgnoreFile = ".linterlyignore"
+// IgnoreFileName は除外パターンファイルの名前。
+const IgnoreFileName = ".linterlyignore"
 
 // IgnorePatterns は有効な除外パターン一覧を返す。
-// .linterlyignore が存在すればそちらを優先し、設定ファイルにも ignore が定義されている場合は warnings に警告を追加する。
+// プロジェクトルート（ProjectRoot）の .linterlyignore が存在すればそちらを優先し、設定ファイルにも ignore が定義されている場合は warnings に警告を追加する。
 // .linterlyignore が存在しない場合は設定ファイルの ignore フィールドを返す。
 // 結果はキャッシュされ、2回目以降の呼び出しではキャッシュを返す。
 func (c *Config) IgnorePatterns() (patterns []string, warnings []string, err error) {
@@ -28,7 +30,7 @@ func (c *Config) IgnorePatterns() (patterns []string, warnings []string, err err
 
 // loadIgnorePatterns は除外パターンをファイルまたは設定から読み込む。
 func (c *Config) loadIgnorePatterns() (patterns []string, warnings []string, err error) {
-	ignorePatterns, fileErr := readLinterlyIgnore(linterlyIgnoreFile)
+	ignorePatterns, fileErr := ReadIgnoreFile(filepath.Join(c.ProjectRoot, IgnoreFileName))
 	if fileErr != nil {
 		if !os.IsNotExist(fileErr) {
 			return nil, nil, fileErr
@@ -44,9 +46,9 @@ func (c *Config) loadIgnorePatterns() (patterns []string, warnings []string, err
 	return ignorePatterns, warnings, nil
 }
 
-// readLinterlyIgnore は .linterlyignore ファイルを読み込み、パターンのリストを返す。
+// ReadIgnoreFile は .linterlyignore ファイルを読み込み、パターンのリストを返す。
 // コメント行（# で始まる）と空行は除外する。
-func readLinterlyIgnore(path string) ([]string, error) {
+func ReadIgnoreFile(path string) ([]string, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -110,7 +110,7 @@ func TestReadLinterlyIgnore_CommentsAndEmptyLines(t *testing.T) {
 	path := filepath.Join(tmpDir, ".linterlyignore")
 	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
 
-	patterns, err := readLinterlyIgnore(path)
+	patterns, err := ReadIgnoreFile(path)
 	require.NoError(t, err)
 	assert.Equal(t, []string{"vendor/", "*.pb.go"}, patterns)
 }
@@ -121,13 +121,13 @@ func TestReadLinterlyIgnore_AllCommentsAndEmpty(t *testing.T) {
 	path := filepath.Join(tmpDir, ".linterlyignore")
 	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
 
-	patterns, err := readLinterlyIgnore(path)
+	patterns, err := ReadIgnoreFile(path)
 	require.NoError(t, err)
 	assert.Empty(t, patterns)
 }
 
 func TestReadLinterlyIgnore_FileNotFound(t *testing.T) {
-	_, err := readLinterlyIgnore("/nonexistent/.linterlyignore")
+	_, err := ReadIgnoreFile("/nonexistent/.linterlyignore")
 	require.Error(t, err)
 	assert.True(t, os.IsNotExist(err))
 }
@@ -138,7 +138,7 @@ func TestReadLinterlyIgnore_WithNegationPattern(t *testing.T) {
 	path := filepath.Join(tmpDir, ".linterlyignore")
 	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
 
-	patterns, err := readLinterlyIgnore(path)
+	patterns, err := ReadIgnoreFile(path)
 	require.NoError(t, err)
 	assert.Equal(t, []string{"vendor/", "!vendor/important.go"}, patterns)
 }
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ousiassllc/linterly/internal/config/extends"
+	"github.com/spf13/viper"
+)
+
+// Load は設定ファイルを読み込み、バリデーション済みの Config を返す。
+// configPath が空でない場合はそのパスのみを読み込む。
+// 空の場合は探索順序に従って設定ファイルを探す。
+func Load(configPath string) (*Config, error) {
+	return LoadFrom(configPath, ".")
+}
+
+// LoadOptions は設定ファイルの読み込み方法の指定。
+type LoadOptions struct {
+	// Lenient が true の場合、未知のキー・型の不一致をエラーにしない（--strict-config=false）
+	Lenient bool
+}
+
+// LoadFrom は Load と同様に設定ファイルを読み込む。設定ファイルを自動探索する場合は、
+// startPath（チェック対象のパス）から親ディレクトリへ順に探索し、見つけたディレクトリを ProjectRoot とする。
+func LoadFrom(configPath, startPath string) (*Config, error) {
+	return LoadWithOptions(configPath, startPath, LoadOptions{})
+}
+
+// LoadWithOptions は LoadFrom と同様に設定ファイルを読み込む。opts.Lenient が false の場合は、
+// 設定ファイル（extends の継承元を含む）の未知のキー・型の不一致を位置付きの ValidationErrors として返す。
+func LoadWithOptions(configPath, startPath string, opts LoadOptions) (*Config, error) {
+	v := viper.New()
+
+	explicit, err := findAndReadConfig(v, configPath, startPath)
+	if err != nil {
+		// 明示指定のパスが見つからない場合はエラー
+		if explicit {
+			return nil, err
+		}
+		// 自動探索で見つからない場合はデフォルト Config を返す
+		var cfgErr *ConfigError
+		if errors.As(err, &cfgErr) && cfgErr.Code == "err.config_not_found" {
+			cfg := defaultConfig()
+			cfg.lenient = opts.Lenient
+			return cfg, nil
+		}
+		return nil, err
+	}
+
+	// --- 設定ファイルが見つかった場合の既存ロジック ---
+	configFile := v.ConfigFileUsed()
+	unchecked := &[]string{}
+	doc, strictErr := checkFile(configFile, opts.Lenient, unchecked)
+	if v, err = withExtends(v, opts.Lenient, unchecked); err != nil {
+		return nil, err
+	}
+
+	// rules セクションの存在チェック（未知のキーがあれば合わせて返す。"rule" 等の誤記の場合）
+	if !v.IsSet("rules") {
+		rulesErr := &ConfigError{
+			Code:    "validation.rules_required",
+			Message: `"rules" section is required`,
+			File:    configFile,
+		}
+		var valErrs *ValidationErrors
+		if errors.As(strictErr, &valErrs) {
+			valErrs.Errors = append(valErrs.Errors, rulesErr)
+			return nil, valErrs
+		}
+		return nil, rulesErr
+	}
+	if strictErr != nil {
+		return nil, strictErr
+	}
+
+	// 設定ファイルで指定したキーのみデフォルト値に重ねる
+	cfg := defaultConfig()
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, &ConfigError{
+			Code:    "err.config_parse",
+			Message: fmt.Sprintf("failed to parse config file: %s", err),
+			Detail:  err.Error(),
+			File:    configFile,
+		}
+	}
+	cfg.lenient = opts.Lenient
+	cfg.unchecked = unchecked
+
+	if err := validate(cfg); err != nil {
+		return nil, locateErrors(err, configFile, doc)
+	}
+
+	// 明示指定の設定ファイルはカレントディレクトリをプロジェクトルートとする（従来の動作）
+	if !explicit {
+		cfg.ProjectRoot = filepath.Dir(configFile)
+	}
+
+	return cfg, nil
+}
+
+// withExtends は extends で指定した継承元の設定を v の設定ファイルの内容に重ねた viper を返す。
+// lenient が false の場合は継承元の設定ファイルの未知のキー・型の不一致も検出する（unchecked は checkFile と同じ）。
+func withExtends(v *viper.Viper, lenient bool, unchecked *[]string) (*viper.Viper, error) {
+	check := func(path string) error {
+		_, err := checkFile(path, lenient, unchecked)
+		return err
+	}
+	merged, err := extends.Apply(v, check)
+	var extErr *extends.Error
+	if errors.As(err, &extErr) {
+		return nil, &ConfigError{Code: extErr.Code, Message: extErr.Message, Detail: extErr.Detail}
+	}
+	return merged, err
+}
+
+// wrapViperError は viper の ReadInConfig エラーを ConfigError にラップする。
+func wrapViperError(path string, err error) *ConfigError {
+	var notFoundErr viper.ConfigFileNotFoundError
+	if os.IsNotExist(err) || errors.As(err, &notFoundErr) {
+		return &ConfigError{
+			Code:    "err.config_not_found",
+			Message: err.Error(),
+		}
+	}
+	return &ConfigError{
+		Code:    "err.config_parse",
+		Message: err.Error(),
+		Detail:  err.Error(),
+		File:    path,
+	}
+}
+
+// findAndReadConfig は探索順序に従って設定ファイルを見つけて読み込む。
+// explicit は、ユーザーが明示的にパスを指定したかどうかを示す。
+func findAndReadConfig(v *viper.Viper, configPath, startPath string) (explicit bool, err error) {
+	if configPath != "" {
+		v.SetConfigFile(configPath)
+		if err := v.ReadInConfig(); err != nil {
+			return true, wrapViperError(configPath, err)
+		}
+		return true, nil
+	}
+
+	// LINTERLY_CONFIG 環境変数
+	if envPath := os.Getenv("LINTERLY_CONFIG"); envPath != "" {
+		v.SetConfigFile(envPath)
+		if err := v.ReadInConfig(); err != nil {
+			return true, wrapViperError(envPath, err)
+		}
+		return true, nil
+	}
+
+	// startPath から親ディレクトリへ順に .linterly.yml / .linterly.yaml を探す（継承元の設定ファイルがあればその最上位）
+	path, err := findRootConfigFile(startPath)
+	if err != nil {
+		return false, err
+	}
+	if path == "" {
+		return false, &ConfigError{
+			Code:    "err.config_not_found",
+			Message: "config file not found",
+		}
+	}
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return false, wrapViperError(path, err)
+	}
+	return false, nil
+}
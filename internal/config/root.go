@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// FindConfigFile は startPath（ファイルの場合はそのディレクトリ）から親ディレクトリへ順に
+// 設定ファイルを探し、最も近いものの絶対パスを返す。見つからない場合は空文字列を返す。
+// .git を含むディレクトリ（リポジトリのルート）より上のディレクトリは探索しない。
+func FindConfigFile(startPath string) (string, error) {
+	dir, err := filepath.Abs(startPath)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	for {
+		if path := ConfigFileIn(dir); path != "" {
+			return path, nil
+		}
+		if isRepositoryRoot(dir) {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// ConfigFileIn はディレクトリ直下の設定ファイル（.linterly.yml / .linterly.yaml）のパスを返す。
+// 存在しない場合は空文字列を返す。
+func ConfigFileIn(dir string) string {
+	for _, name := range DefaultConfigFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// findRootConfigFile は startPath から最も近い設定ファイルを探し、root: true でなければ親ディレクトリの
+// 設定ファイルをさらに探して、継承元をたどった最上位の設定ファイルを返す。
+// 途中の設定ファイルは走査時にネストした設定ファイルのスコープとして読み込む。
+func findRootConfigFile(startPath string) (string, error) {
+	path, err := FindConfigFile(startPath)
+	for err == nil && path != "" {
+		dir := filepath.Dir(path)
+		if isRepositoryRoot(dir) || isRootConfig(path) || filepath.Dir(dir) == dir {
+			break
+		}
+		parent, perr := FindConfigFile(filepath.Dir(dir))
+		if perr != nil || parent == "" {
+			break
+		}
+		path = parent
+	}
+	return path, err
+}
+
+// isRootConfig は設定ファイルに root: true が指定されているかを返す。読み込めない場合は false。
+func isRootConfig(path string) bool {
+	v := viper.New()
+	v.SetConfigFile(path)
+	return v.ReadInConfig() == nil && v.GetBool("root")
+}
+
+// isRepositoryRoot はディレクトリに .git が含まれるか（Git リポジトリのルートか）を返す。
+func isRepositoryRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindConfigFile(t *testing.T) {
+	// repo/.linterly.yml, repo/.git, repo/pkg/sub/main.go
+	tmpDir := t.TempDir()
+	repo := filepath.Join(tmpDir, "repo")
+	sub := filepath.Join(repo, "pkg", "sub")
+	require.NoError(t, os.MkdirAll(sub, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".linterly.yml"), []byte("rules: {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(sub, "main.go"), []byte("package sub\n"), 0644))
+
+	// サブディレクトリ・ファイルから親ディレクトリへ探索する
+	for _, start := range []string{sub, filepath.Join(sub, "main.go"), repo} {
+		got, err := FindConfigFile(start)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(repo, ".linterly.yml"), got, start)
+	}
+
+	// より近い設定ファイルが優先される
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "pkg", ".linterly.yaml"), []byte("rules: {}\n"), 0644))
+	got, err := FindConfigFile(sub)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repo, "pkg", ".linterly.yaml"), got)
+
+	// .git を含むディレクトリより上は探索しない
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".linterly.yml"), []byte("rules: {}\n"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(repo, ".linterly.yml")))
+	require.NoError(t, os.Remove(filepath.Join(repo, "pkg", ".linterly.yaml")))
+	got, err = FindConfigFile(sub)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestLoadFrom_ProjectRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	sub := filepath.Join(tmpDir, "pkg")
+	require.NoError(t, os.MkdirAll(sub, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".linterly.yml"), []byte("rules:\n  max_lines_per_file: 250\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, IgnoreFileName), []byte("*.pb.go\n"), 0644))
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(origDir) }()
+	require.NoError(t, os.Chdir(sub))
+
+	// サブディレクトリから実行しても親ディレクトリの設定ファイルと .linterlyignore を使う
+	cfg, err := LoadFrom("", ".")
+	require.NoError(t, err)
+	assert.Equal(t, 250, cfg.Rules.MaxLinesPerFile)
+	assert.Equal(t, tmpDir, cfg.ProjectRoot)
+	patterns, _, err := cfg.IgnorePatterns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.pb.go"}, patterns)
+
+	// 明示指定の設定ファイルはカレントディレクトリを基準とする
+	cfg, err = LoadFrom(filepath.Join(tmpDir, ".linterly.yml"), ".")
+	require.NoError(t, err)
+	assert.Empty(t, cfg.ProjectRoot)
+
+	// サブディレクトリの設定ファイルは継承元の最上位の設定ファイルのディレクトリをプロジェクトルートとする
+	subCfg := filepath.Join(sub, ".linterly.yml")
+	require.NoError(t, os.WriteFile(subCfg, []byte("rules:\n  max_lines_per_file: 100\n"), 0644))
+	cfg, err = LoadFrom("", ".")
+	require.NoError(t, err)
+	assert.Equal(t, 250, cfg.Rules.MaxLinesPerFile)
+	assert.Equal(t, tmpDir, cfg.ProjectRoot)
+
+	// root: true の設定ファイルは継承しないため、そのディレクトリがプロジェクトルートになる
+	require.NoError(t, os.WriteFile(subCfg, []byte("root: true\nrules:\n  max_lines_per_file: 100\n"), 0644))
+	cfg, err = LoadFrom("", ".")
+	require.NoError(t, err)
+	assert.Equal(t, 100, cfg.Rules.MaxLinesPerFile)
+	assert.Equal(t, sub, cfg.ProjectRoot)
+}
+
+func TestFindRootConfigFile(t *testing.T) {
+	// repo/.linterly.yml, repo/mid/.linterly.yml, repo/mid/leaf/.linterly.yml
+	repo := t.TempDir()
+	mid := filepath.Join(repo, "mid")
+	leaf := filepath.Join(mid, "leaf")
+	require.NoError(t, os.MkdirAll(leaf, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0755))
+	for _, dir := range []string{repo, mid, leaf} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".linterly.yml"), []byte("rules: {}\n"), 0644))
+	}
+
+	// 最も近い設定ファイルではなく、継承元をたどった最上位の設定ファイルを返す
+	got, err := findRootConfigFile(leaf)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repo, ".linterly.yml"), got)
+
+	// root: true の設定ファイルで探索を止める
+	require.NoError(t, os.WriteFile(filepath.Join(mid, ".linterly.yml"), []byte("root: true\n"), 0644))
+	got, err = findRootConfigFile(leaf)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(mid, ".linterly.yml"), got)
+
+	// 途中に設定ファイルがないディレクトリは読み飛ばす
+	require.NoError(t, os.Remove(filepath.Join(mid, ".linterly.yml")))
+	got, err = findRootConfigFile(leaf)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repo, ".linterly.yml"), got)
+}
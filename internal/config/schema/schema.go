@@ -0,0 +1,196 @@
+// Package schema は設定ファイル（.linterly.yml）の JSON Schema を config.Config の定義から生成する。
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/i18n"
+)
+
+// Draft は生成する JSON Schema のバージョン（YAML Language Server が対応する draft-07）。
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// descriptions は設定項目（キーはドット区切り。マップの値は "*"、リストの要素は "[]"）ごとの説明。
+// Config に項目を追加した場合はここにも追加する（schema_test.go で検証する）。
+var descriptions = map[string]string{
+	"rules":                                  "Line count rules.",
+	"rules.max_lines_per_file":               "Maximum number of lines per file.",
+	"rules.max_lines_per_directory":          "Maximum total number of lines of the files directly under a directory.",
+	"rules.warning_threshold":                "Width of the warning zone, as a percentage of the limit.",
+	"rules.warning_margin_lines":             "Width of the warning zone in lines. Overrides warning_threshold when greater than 0.",
+	"rules.warning_mode":                     "Place the warning zone above the limit (grace) or below it (early).",
+	"rules.warnings":                         "Per-rule overrides of the warning zone, keyed by rule name.",
+	"rules.warnings.*.mode":                  "Warning mode for this rule (grace or early).",
+	"rules.warnings.*.threshold":             "Warning threshold (%) for this rule.",
+	"rules.warnings.*.margin_lines":          "Warning margin in lines for this rule.",
+	"rules.severity":                         "Per-rule severity, keyed by rule name: error (default), warn, info or off.",
+	"rules.max_script_lines_per_component":   "Maximum lines of the script section of Vue/Svelte/Astro components (0 disables the check).",
+	"rules.max_template_lines_per_component": "Maximum lines of the template section of Vue/Svelte/Astro components (0 disables the check).",
+	"rules.max_line_length":                  "Maximum display width of a line (0 disables the check).",
+	"rules.max_lines_per_function":           "Maximum number of lines per function (0 disables the check).",
+	"rules.max_declarations_per_file":        "Maximum number of top-level declarations per file (0 disables the check).",
+	"rules.max_lines_per_test_file":          "Maximum number of lines per test file (0 uses max_lines_per_file).",
+	"rules.exclude_tests_from_directory":     "Leave test files out of directory totals.",
+	"count_mode":                             "Count all lines, or only code lines excluding comments and blank lines.",
+	"ignore":                                 "Patterns of files to exclude (gitignore syntax). Ignored when .linterlyignore exists.",
+	"default_excludes":                       "Apply the built-in exclude list (node_modules/, dist/, lock files, ...).",
+	"language":                               "Language of messages.",
+	"update_check":                           "Check for a new version of linterly.",
+	"skip_generated":                         "Skip files whose first lines match generated_patterns.",
+	"generated_patterns":                     "Regular expressions that mark a file as generated.",
+	"respect_gitattributes":                  "Exclude files marked linguist-generated or linguist-vendored in .gitattributes.",
+	"respect_gitignore":                      "Exclude files matched by .gitignore, .git/info/exclude and core.excludesFile.",
+	"git_tracked":                            "Check only the files tracked in the git index.",
+	"report_skipped_binary":                  "Report files skipped as binary.",
+	"test_patterns":                          "Patterns of test files (gitignore syntax). Replaces the defaults.",
+	"fail_on":                                "Lowest severity that fails the check.",
+	"max_warnings":                           "Fail when the number of warnings exceeds this value (negative for no limit).",
+	"budgets":                                "Limits on the total line count of the files matching a path pattern.",
+	"budgets[].path":                         "Path pattern relative to the project root (.gitattributes syntax, ** supported).",
+	"budgets[].max_lines":                    "Maximum total number of lines of the matching files.",
+	"extends":                                "Configs to inherit from: paths relative to this file, or bundled presets (linterly:recommended, linterly:strict).",
+	"root":                                   "Do not inherit the config files of parent directories.",
+}
+
+// enums は値を列挙できる設定項目の値の一覧。
+var enums = map[string][]string{
+	"count_mode":            {config.CountModeAll, config.CountModeCodeOnly},
+	"language":              i18n.SupportedLanguages(),
+	"rules.warning_mode":    {config.WarningModeGrace, config.WarningModeEarly},
+	"rules.warnings.*.mode": {config.WarningModeGrace, config.WarningModeEarly},
+	"rules.severity.*":      {config.SeverityError, config.SeverityWarn, config.SeverityInfo, config.SeverityOff},
+	"fail_on":               {config.FailOnError, config.FailOnWarn, config.FailOnInfo},
+}
+
+// ruleKeyed はルール名をキーとするマップの設定項目。
+var ruleKeyed = map[string]bool{"rules.warnings": true, "rules.severity": true}
+
+// minimums・maximums は整数の設定項目の最小値・最大値。
+var minimums = map[string]int{
+	"rules.max_lines_per_file":               1,
+	"rules.max_lines_per_directory":          1,
+	"rules.warning_threshold":                0,
+	"rules.warning_margin_lines":             0,
+	"rules.warnings.*.threshold":             0,
+	"rules.warnings.*.margin_lines":          0,
+	"rules.max_script_lines_per_component":   0,
+	"rules.max_template_lines_per_component": 0,
+	"rules.max_line_length":                  0,
+	"rules.max_lines_per_function":           0,
+	"rules.max_declarations_per_file":        0,
+	"rules.max_lines_per_test_file":          0,
+	"budgets[].max_lines":                    1,
+}
+
+var maximums = map[string]int{
+	"rules.warning_threshold":    100,
+	"rules.warnings.*.threshold": 100,
+}
+
+// required はリストの要素（構造体）の必須の項目。
+var required = map[string][]string{
+	"budgets[]": {"path", "max_lines"},
+}
+
+// Generate は config.Config の JSON Schema を返す。各項目には説明・型・デフォルト値（config.Default）を、
+// 値を列挙できる項目には enum を設定する。未知のキーは許可しない（--strict-config と同じ）。
+func Generate() map[string]any {
+	s := object(reflect.TypeFor[config.Config](), reflect.ValueOf(*config.Default()), "")
+	s["$schema"] = Draft
+	s["title"] = "Linterly configuration (.linterly.yml)"
+	// extends は単一の文字列も指定できる
+	props := s["properties"].(map[string]any)
+	props["extends"] = map[string]any{
+		"description": descriptions["extends"],
+		"anyOf": []any{
+			map[string]any{"type": "string"},
+			map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+	}
+	return s
+}
+
+// JSON は Generate の結果をインデント付きの JSON として返す。
+func JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(Generate(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// object は構造体 t のスキーマを返す。def はデフォルト値（無効な Value の場合はデフォルト値を設定しない）。
+func object(t reflect.Type, def reflect.Value, key string) map[string]any {
+	props := map[string]any{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if !f.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		var fieldDef reflect.Value
+		if def.IsValid() {
+			fieldDef = def.Field(i)
+		}
+		props[name] = property(f.Type, fieldDef, join(key, name))
+	}
+	return map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+}
+
+// property は設定項目 key（型 t、デフォルト値 def）のスキーマを返す。
+func property(t reflect.Type, def reflect.Value, key string) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		def = reflect.Value{}
+	}
+
+	var s map[string]any
+	switch t.Kind() {
+	case reflect.Struct:
+		s = object(t, def, key)
+		if r, ok := required[key]; ok {
+			s["required"] = r
+		}
+		def = reflect.Value{}
+	case reflect.Map:
+		s = map[string]any{"type": "object", "additionalProperties": property(t.Elem(), reflect.Value{}, key+".*")}
+		if ruleKeyed[key] {
+			s["propertyNames"] = map[string]any{"enum": config.RuleNames()}
+		}
+	case reflect.Slice:
+		s = map[string]any{"type": "array", "items": property(t.Elem(), reflect.Value{}, key+"[]")}
+	case reflect.Int:
+		s = map[string]any{"type": "integer"}
+		if n, ok := minimums[key]; ok {
+			s["minimum"] = n
+		}
+		if n, ok := maximums[key]; ok {
+			s["maximum"] = n
+		}
+	case reflect.Bool:
+		s = map[string]any{"type": "boolean"}
+	default:
+		s = map[string]any{"type": "string"}
+	}
+
+	if d, ok := descriptions[key]; ok {
+		s["description"] = d
+	}
+	if e, ok := enums[key]; ok {
+		s["enum"] = e
+	}
+	if def.IsValid() && !(def.Kind() == reflect.Slice && def.IsNil()) && !(def.Kind() == reflect.Map && def.IsNil()) {
+		s["default"] = def.Interface()
+	}
+	return s
+}
+
+func join(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
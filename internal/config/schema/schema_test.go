@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/i18n"
+)
+
+// fields は構造体 t の設定項目のキー（Generate と同じ表記）と型を返す。
+func fields(t reflect.Type, key string, out map[string]reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if !f.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		k := join(key, name)
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		out[k] = ft
+		switch ft.Kind() {
+		case reflect.Struct:
+			fields(ft, k, out)
+		case reflect.Map, reflect.Slice:
+			if ft.Elem().Kind() == reflect.Struct {
+				sep := ".*"
+				if ft.Kind() == reflect.Slice {
+					sep = "[]"
+				}
+				fields(ft.Elem(), k+sep, out)
+			}
+		}
+	}
+}
+
+// lookup は Generate の結果から key の項目のスキーマを返す。
+func lookup(t *testing.T, s map[string]any, key string) map[string]any {
+	t.Helper()
+	for _, part := range strings.Split(key, ".") {
+		name, isItem := strings.CutSuffix(part, "[]")
+		if name == "*" {
+			s = s["additionalProperties"].(map[string]any)
+		} else {
+			props, ok := s["properties"].(map[string]any)
+			require.True(t, ok, "%s: not an object", key)
+			s, ok = props[name].(map[string]any)
+			require.True(t, ok, "%s: missing in schema", key)
+		}
+		if isItem {
+			s = s["items"].(map[string]any)
+		}
+	}
+	return s
+}
+
+// TestGenerate_InSyncWithConfig は config.Config のすべての項目がスキーマに説明・正しい型付きで含まれ、
+// descriptions 等に Config にない項目が残っていないことを検証する。
+func TestGenerate_InSyncWithConfig(t *testing.T) {
+	s := Generate()
+	types := map[string]reflect.Type{}
+	fields(reflect.TypeFor[config.Config](), "", types)
+
+	wantType := map[reflect.Kind]string{
+		reflect.Struct: "object",
+		reflect.Map:    "object",
+		reflect.Slice:  "array",
+		reflect.Int:    "integer",
+		reflect.Bool:   "boolean",
+		reflect.String: "string",
+	}
+	for key, ft := range types {
+		p := lookup(t, s, key)
+		assert.NotEmpty(t, p["description"], "%s: description", key)
+		assert.NotEmpty(t, descriptions[key], "%s: descriptions", key)
+		if key == "extends" {
+			continue
+		}
+		assert.Equal(t, wantType[ft.Kind()], p["type"], "%s: type", key)
+	}
+
+	// 説明・制約の対象が Config に存在する
+	for key := range descriptions {
+		assert.Contains(t, types, key, "descriptions")
+	}
+	for key := range minimums {
+		assert.Contains(t, types, key, "minimums")
+	}
+	for key := range maximums {
+		assert.Contains(t, types, key, "maximums")
+	}
+	for key := range required {
+		_, ok := types[strings.TrimSuffix(key, "[]")]
+		assert.True(t, ok, "required: %s", key)
+	}
+	for key := range ruleKeyed {
+		assert.Contains(t, types, key, "ruleKeyed")
+	}
+	for key := range enums {
+		_, ok := types[key]
+		_, okMap := types[strings.TrimSuffix(key, ".*")]
+		assert.True(t, ok || okMap, "enums: %s", key)
+	}
+}
+
+func TestGenerate_Defaults(t *testing.T) {
+	s := Generate()
+	assert.Equal(t, Draft, s["$schema"])
+	assert.Equal(t, config.DefaultMaxLinesPerFile, lookup(t, s, "rules.max_lines_per_file")["default"])
+	assert.Equal(t, config.DefaultMaxLinesPerDirectory, lookup(t, s, "rules.max_lines_per_directory")["default"])
+	assert.Equal(t, config.DefaultWarningThreshold, lookup(t, s, "rules.warning_threshold")["default"])
+	assert.Equal(t, config.CountModeAll, lookup(t, s, "count_mode")["default"])
+	assert.Equal(t, config.FailOnError, lookup(t, s, "fail_on")["default"])
+	assert.Equal(t, true, lookup(t, s, "default_excludes")["default"])
+	// 既定値のない項目には default を設定しない
+	assert.NotContains(t, lookup(t, s, "budgets"), "default")
+}
+
+func TestGenerate_Enums(t *testing.T) {
+	s := Generate()
+	assert.Equal(t, []string{config.CountModeAll, config.CountModeCodeOnly}, lookup(t, s, "count_mode")["enum"])
+	assert.Equal(t, i18n.SupportedLanguages(), lookup(t, s, "language")["enum"])
+	assert.Contains(t, lookup(t, s, "language")["enum"], lookup(t, s, "language")["default"])
+	assert.Equal(t, []string{"error", "warn", "info", "off"}, lookup(t, s, "rules.severity.*")["enum"])
+	assert.Equal(t, map[string]any{"enum": config.RuleNames()}, lookup(t, s, "rules.severity")["propertyNames"])
+}
+
+func TestJSON(t *testing.T) {
+	data, err := JSON()
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), "}\n"))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, false, got["additionalProperties"])
+	assert.Contains(t, got["properties"], "rules")
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/spf13/viper"
+
+	"github.com/ousiassllc/linterly/internal/config/strict"
+)
+
+// scopeKeys はネストした設定ファイルで指定できるトップレベルのキー。それ以外のキー（走査の設定・budgets・
+// fail_on 等）はプロジェクトルートの設定のみを使うため、ネストした設定ファイルで指定した場合はエラーにする。
+var scopeKeys = []string{"rules", "count_mode", "extends", "root"}
+
+// LoadScope はサブディレクトリに置かれた設定ファイル（ネストした設定ファイル）を読み込み、
+// parent（親ディレクトリのスコープの設定）に重ねた Config を返す。root: true の場合は parent を継承せず
+// デフォルト値に重ねる。プロジェクトルートの設定ファイルと異なり rules セクションは省略でき、
+// scopeKeys 以外のキーは指定できない。extends で指定した継承元の設定は、スコープの設定ファイルの値の下に
+// 重ねる（継承元の scopeKeys 以外のキーは使わない）。
+// 未知のキー・型の不一致の検出（LoadOptions.Lenient）は parent に従う。
+// parent に適用された CLI フラグの上書きは、スコープの設定ファイルの値より優先する。
+// エラーはいずれも設定ファイルのパスを含む ConfigError または ValidationErrors として返す。
+func LoadScope(path string, parent *Config) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, wrapViperError(path, err)
+	}
+	doc, err := checkFile(path, parent.lenient, parent.unchecked)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScopeKeys(v, path, doc); err != nil {
+		return nil, err
+	}
+	if v, err = withExtends(v, parent.lenient, parent.unchecked); err != nil {
+		return nil, inFile(err, path)
+	}
+
+	cfg := parent.clone()
+	if v.GetBool("root") {
+		cfg = defaultConfig()
+	}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, &ConfigError{
+			Code:    "err.config_parse",
+			Message: fmt.Sprintf("failed to parse config file: %s", err),
+			Detail:  err.Error(),
+			File:    path,
+		}
+	}
+
+	cfg.lenient = parent.lenient
+	cfg.unchecked = parent.unchecked
+	if err := cfg.ApplyOverrides(parent.overrides); err != nil {
+		return nil, inFile(locateErrors(err, path, doc), path)
+	}
+	cfg.ProjectRoot = parent.ProjectRoot
+	return cfg, nil
+}
+
+// checkScopeKeys はネストした設定ファイルに scopeKeys 以外の（プロジェクトルートの設定でのみ使う）キーが
+// 指定されていないかを検査し、指定されている場合は位置付きの ValidationErrors を返す。
+func checkScopeKeys(v *viper.Viper, path string, doc *strict.Document) error {
+	var errs []*ConfigError
+	t := reflect.TypeFor[Config]()
+	for i := range t.NumField() {
+		key := t.Field(i).Tag.Get("mapstructure")
+		if key == "" || key == "-" || slices.Contains(scopeKeys, key) || !v.InConfig(key) {
+			continue
+		}
+		e := &ConfigError{
+			Code:    "validation.scope_key",
+			Message: fmt.Sprintf(`"%s" can only be set in the project root config, not in a nested config file`, key),
+			Detail:  key,
+			File:    path,
+		}
+		if doc != nil {
+			if line, col, ok := doc.Locate(key); ok {
+				e.Line, e.Column = line, col
+			}
+		}
+		errs = append(errs, e)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationErrors{Errors: errs}
+}
+
+// inFile は位置が設定されていない ConfigError・ValidationErrors に設定ファイルのパスを設定して返す。
+func inFile(err error, path string) error {
+	var valErrs *ValidationErrors
+	if errors.As(err, &valErrs) {
+		for _, e := range valErrs.Errors {
+			if e.File == "" {
+				e.File = path
+			}
+		}
+	}
+	var cfgErr *ConfigError
+	if errors.As(err, &cfgErr) && cfgErr.File == "" {
+		cfgErr.File = path
+	}
+	return err
+}
+
+// clone は Config のコピーを返す。スライス・マップはコピーし、重ねて読み込んでも元の Config を変更しない。
+func (c *Config) clone() *Config {
+	cp := *c
+	cp.Rules.Warnings = maps.Clone(c.Rules.Warnings)
+	cp.Rules.Severity = maps.Clone(c.Rules.Severity)
+	cp.Ignore = slices.Clone(c.Ignore)
+	cp.GeneratedPatterns = slices.Clone(c.GeneratedPatterns)
+	cp.TestPatterns = slices.Clone(c.TestPatterns)
+	cp.Budgets = slices.Clone(c.Budgets)
+	cp.Extends = slices.Clone(c.Extends)
+	cp.ignoreCache = nil
+	return &cp
+}
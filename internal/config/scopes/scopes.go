@@ -0,0 +1,69 @@
+// Package scopes はサブディレクトリに置かれた設定ファイル（ネストした設定ファイル）のスコープを扱う。
+package scopes
+
+import (
+	"path"
+	"path/filepath"
+
+	"github.com/ousiassllc/linterly/internal/config"
+)
+
+// Tree はプロジェクトルートの設定と、走査中に見つかったネストした設定ファイルのスコープ。
+// 各スコープの設定は親ディレクトリのスコープの設定を継承する（root: true の場合を除く）。
+type Tree struct {
+	root string
+	base *config.Config
+	// configs はネストした設定ファイルが置かれたディレクトリ（プロジェクトルート相対）ごとの設定
+	configs map[string]*config.Config
+}
+
+// New はプロジェクトルートの設定 cfg をルートとする空の Tree を返す。
+// 各ディレクトリの設定ファイルは LoadDir で親ディレクトリから順に追加する。
+func New(projectRoot string, cfg *config.Config) *Tree {
+	return &Tree{root: projectRoot, base: cfg, configs: make(map[string]*config.Config)}
+}
+
+// LoadDir はディレクトリ（プロジェクトルート相対）直下の設定ファイルを読み込み、スコープとして追加する。
+// 設定ファイルが存在しない場合、およびプロジェクトルートの場合は何もしない。
+// 読み込みのエラーは設定ファイルのパスを含む config.ConfigError または config.ValidationErrors として返す。
+func (t *Tree) LoadDir(relDir string) error {
+	if relDir == "." {
+		return nil
+	}
+	if _, ok := t.configs[relDir]; ok {
+		return nil
+	}
+	file := config.ConfigFileIn(filepath.Join(t.root, filepath.FromSlash(relDir)))
+	if file == "" {
+		return nil
+	}
+	cfg, err := config.LoadScope(file, t.Config(path.Dir(relDir)))
+	if err != nil {
+		return err
+	}
+	t.configs[relDir] = cfg
+	return nil
+}
+
+// Config はディレクトリ（プロジェクトルート相対）に適用する、最も近いスコープの設定を返す。
+func (t *Tree) Config(relDir string) *config.Config {
+	for {
+		if cfg, ok := t.configs[relDir]; ok {
+			return cfg
+		}
+		parent := path.Dir(relDir)
+		if parent == relDir {
+			return t.base
+		}
+		relDir = parent
+	}
+}
+
+// Nested はディレクトリがネストした設定ファイルのスコープ内にある場合にその設定を返す。
+// プロジェクトルートの設定が適用される場合は nil を返す。
+func (t *Tree) Nested(relDir string) *config.Config {
+	if cfg := t.Config(relDir); cfg != t.base {
+		return cfg
+	}
+	return nil
+}
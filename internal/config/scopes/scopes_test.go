@@ -0,0 +1,134 @@
+package scopes
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTree(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		".linterly.yml":                  "rules:\n  max_lines_per_file: 300\n  severity:\n    max_lines_per_directory: warn\ncount_mode: code_only\n",
+		"apps/web/.linterly.yml":         "rules:\n  max_lines_per_file: 500\n  severity:\n    max_lines_per_file: info\n",
+		"apps/web/legacy/.linterly.yaml": "root: true\nrules:\n  max_lines_per_directory: 900\n",
+		"libs/invalid/.linterly.yml":     "rules:\n  max_lines_per_file: -1\n",
+		"apps/web/legacy/src/main.go":    "package src\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	root, err := config.LoadFrom(filepath.Join(tmpDir, ".linterly.yml"), ".")
+	require.NoError(t, err)
+	tree := New(tmpDir, root)
+	for _, dir := range []string{".", "apps", "apps/web", "apps/web/legacy", "apps/web/legacy/src"} {
+		require.NoError(t, tree.LoadDir(dir))
+	}
+
+	// プロジェクトルートの設定
+	assert.Same(t, root, tree.Config("apps"))
+	assert.Nil(t, tree.Nested("apps"))
+	assert.Nil(t, tree.Nested("."))
+
+	// 親の設定を継承し、指定したキーのみ上書きする（マップはマージされる）
+	web := tree.Nested("apps/web/components")
+	require.NotNil(t, web)
+	assert.Equal(t, 500, web.Rules.MaxLinesPerFile)
+	assert.Equal(t, config.CountModeCodeOnly, web.CountMode)
+	assert.Equal(t, map[string]string{"max_lines_per_directory": "warn", "max_lines_per_file": "info"}, web.Rules.Severity)
+	assert.Equal(t, map[string]string{"max_lines_per_directory": "warn"}, root.Rules.Severity, "親の設定は変更しない")
+
+	// root: true の場合は継承せずデフォルト値に重ねる
+	legacy := tree.Nested("apps/web/legacy/src")
+	require.NotNil(t, legacy)
+	assert.Equal(t, config.DefaultMaxLinesPerFile, legacy.Rules.MaxLinesPerFile)
+	assert.Equal(t, 900, legacy.Rules.MaxLinesPerDirectory)
+	assert.Equal(t, config.CountModeAll, legacy.CountMode)
+
+	// 不正な設定はファイルのパスを含むエラー
+	err = tree.LoadDir("libs/invalid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join(tmpDir, "libs", "invalid", ".linterly.yml"))
+
+	// CLI フラグの上書きはスコープの設定ファイルの値より優先する
+	maxLines := 800
+	require.NoError(t, root.ApplyOverrides(&config.Overrides{MaxLinesPerFile: &maxLines}))
+	tree = New(tmpDir, root)
+	for _, dir := range []string{"apps", "apps/web", "apps/web/legacy"} {
+		require.NoError(t, tree.LoadDir(dir))
+	}
+	assert.Equal(t, 800, tree.Config("apps/web").Rules.MaxLinesPerFile)
+	assert.Equal(t, 800, tree.Config("apps/web/legacy").Rules.MaxLinesPerFile)
+}
+
+func TestTree_Extends(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		".linterly.yml":          "extends: linterly:recommended\nrules:\n  max_lines_per_file: 350\n",
+		"shared/legacy.yml":      "rules:\n  max_lines_per_file: 900\ncount_mode: code_only\n",
+		"apps/old/.linterly.yml": "extends: ../../shared/legacy.yml\n",
+		"apps/bad/.linterly.yml": "extends: ../../shared/missing.yml\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	// rules セクションは継承元（プリセット）から継承できる
+	root, err := config.LoadFrom(filepath.Join(tmpDir, ".linterly.yml"), ".")
+	require.NoError(t, err)
+	assert.Equal(t, 350, root.Rules.MaxLinesPerFile)
+	assert.Equal(t, 500, root.Rules.MaxLinesPerTestFile)
+	assert.True(t, root.SkipGenerated)
+	assert.Equal(t, []string{"linterly:recommended"}, root.Extends)
+
+	// ネストした設定ファイルの継承元は親のスコープの設定に重なる
+	tree := New(tmpDir, root)
+	require.NoError(t, tree.LoadDir("apps/old"))
+	old := tree.Config("apps/old")
+	assert.Equal(t, 900, old.Rules.MaxLinesPerFile)
+	assert.Equal(t, config.CountModeCodeOnly, old.CountMode)
+	assert.True(t, old.SkipGenerated)
+
+	// 継承元が見つからない場合は ConfigError
+	err = tree.LoadDir("apps/bad")
+	var cfgErr *config.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.Equal(t, "err.extends_not_found", cfgErr.Code)
+	assert.Equal(t, filepath.Join(tmpDir, "shared", "missing.yml"), cfgErr.Detail)
+	assert.Equal(t, filepath.Join(tmpDir, "apps", "bad", ".linterly.yml"), cfgErr.File)
+}
+
+func TestTree_RootOnlyKeys(t *testing.T) {
+	// ネストした設定ファイルではプロジェクトルートの設定でのみ使うキーを指定できない
+	tmpDir := t.TempDir()
+	nested := filepath.Join(tmpDir, "apps", "web", ".linterly.yml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(nested), 0755))
+	src := "rules:\n  max_lines_per_file: 500\nignore:\n  - dist/\nskip_generated: true\nbudgets:\n  - path: \"**\"\n    max_lines: 10\n"
+	require.NoError(t, os.WriteFile(nested, []byte(src), 0644))
+
+	tree := New(tmpDir, config.Default())
+	err := tree.LoadDir("apps/web")
+	var valErrs *config.ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	require.Len(t, valErrs.Errors, 3)
+	for i, want := range []struct {
+		key  string
+		line int
+	}{{"ignore", 4}, {"skip_generated", 5}, {"budgets", 7}} {
+		e := valErrs.Errors[i]
+		assert.Equal(t, "validation.scope_key", e.Code)
+		assert.Equal(t, want.key, e.Detail)
+		assert.Equal(t, nested, e.File)
+		assert.Equal(t, want.line, e.Line)
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/config/strict"
+)
+
+// yamlFormats は YAML の設定ファイルの拡張子。
+var yamlFormats = []string{".yml", ".yaml"}
+
+// checkFile は設定ファイル path を位置情報付きで解析する。lenient が false の場合は Config の定義と照合し、
+// 未知のキー・型の不一致を ValidationErrors として返す（解析結果はエラーの場合も返す）。
+// 位置情報付きの解析は YAML として行い、YAML の設定ファイルの構文エラーは行番号付きの解析エラーを返す。
+// YAML 以外の形式（viper が読み込める TOML 等、YAML として解析できない JSON）はチェックせずに nil を返し、
+// lenient が false の場合は path を unchecked に追加する（呼び出し側で警告する）。
+func checkFile(path string, lenient bool, unchecked *[]string) (*strict.Document, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	skip := func() (*strict.Document, error) {
+		if !lenient && unchecked != nil {
+			*unchecked = append(*unchecked, path)
+		}
+		return nil, nil
+	}
+	if ext != ".json" && !slices.Contains(yamlFormats, ext) {
+		return skip()
+	}
+	doc, err := strict.ParseFile(path)
+	if err != nil {
+		if ext == ".json" {
+			return skip()
+		}
+		if lenient {
+			return nil, nil
+		}
+		cfgErr := &ConfigError{
+			Code:    "err.config_parse",
+			Message: fmt.Sprintf("failed to parse config file as YAML: %s", err),
+			Detail:  err.Error(),
+			File:    path,
+		}
+		var synErr *strict.SyntaxError
+		if errors.As(err, &synErr) {
+			cfgErr.Line = synErr.Line
+		}
+		return nil, cfgErr
+	}
+	if lenient {
+		return doc, nil
+	}
+	issues := doc.Check(reflect.TypeFor[Config]())
+	if len(issues) == 0 {
+		return doc, nil
+	}
+	errs := make([]*ConfigError, len(issues))
+	for i, is := range issues {
+		errs[i] = &ConfigError{Code: is.Code, Message: is.Message, Detail: is.Key, File: path, Line: is.Line, Column: is.Column}
+	}
+	return doc, &ValidationErrors{Errors: errs}
+}
+
+// locateErrors は ValidationErrors の各エラーに、原因となったキーの設定ファイル path 内の位置を設定する。
+// キーはエラーコード（"validation.<key>"）から rules 以下・トップレベルの順に探す。
+func locateErrors(err error, path string, doc *strict.Document) error {
+	var valErrs *ValidationErrors
+	if doc == nil || !errors.As(err, &valErrs) {
+		return err
+	}
+	for _, e := range valErrs.Errors {
+		key := strings.TrimPrefix(e.Code, "validation.")
+		for _, k := range []string{"rules." + key, key} {
+			if line, col, ok := doc.Locate(k); ok {
+				e.File, e.Line, e.Column = path, line, col
+				break
+			}
+		}
+	}
+	return err
+}
+
+// UncheckedFiles は YAML として解析できない形式（TOML 等）のため、--strict-config が有効でも未知のキー・
+// 型の不一致を検出せずに読み込んだ設定ファイル（継承元・読み込み済みのネストした設定ファイルを含む）を返す。
+func (c *Config) UncheckedFiles() []string {
+	if c.unchecked == nil {
+		return nil
+	}
+	return slices.Clone(*c.unchecked)
+}
@@ -0,0 +1,217 @@
+// Package strict は設定ファイルの YAML を構造体の定義（mapstructure タグ）と照合し、
+// 未知のキー・型の不一致を位置（行・列）とともに検出する。
+package strict
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Issue の Code の値（i18n メッセージキーに対応する）。
+const (
+	CodeUnknownKey  = "validation.unknown_key"
+	CodeTypeInteger = "validation.type_integer"
+	CodeTypeBoolean = "validation.type_boolean"
+	CodeTypeString  = "validation.type_string"
+	CodeTypeList    = "validation.type_list"
+	CodeTypeMapping = "validation.type_mapping"
+)
+
+// Issue は設定ファイルで検出した問題。
+type Issue struct {
+	Code string
+	// Key はドット区切りのキー（例: "rules.max_line_per_file"。リストの要素は "budgets[0].path"）
+	Key     string
+	Message string
+	Line    int // 1 始まり
+	Column  int // 1 始まり
+}
+
+// SyntaxError は YAML として解析できない場合のエラー。
+type SyntaxError struct {
+	Line    int // 1 始まり（不明な場合は 0）
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Message
+}
+
+// syntaxErrorPattern は yaml.v3 の構文エラーのメッセージ（"yaml: line 3: ..."）。
+var syntaxErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// Document は位置情報付きで解析した設定ファイル。
+type Document struct {
+	root *yaml.Node
+}
+
+// ParseFile は設定ファイルを読み込んで解析する。
+func ParseFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse は YAML を解析する。解析できない場合は *SyntaxError を返す。
+func Parse(data []byte) (*Document, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		synErr := &SyntaxError{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := syntaxErrorPattern.FindStringSubmatch(err.Error()); m != nil {
+			synErr.Line, _ = strconv.Atoi(m[1])
+			synErr.Message = m[2]
+		}
+		return nil, synErr
+	}
+	d := &Document{}
+	if len(doc.Content) > 0 {
+		d.root = resolve(doc.Content[0])
+	}
+	return d, nil
+}
+
+// Check は文書を t（構造体の型）と照合し、未知のキーと型の不一致を文書内の出現順に返す。
+// キーは mapstructure タグ（大文字・小文字を区別しない）と照合する。null の値は未指定として扱う。
+func (d *Document) Check(t reflect.Type) []Issue {
+	if d.root == nil {
+		return nil
+	}
+	var issues []Issue
+	check(d.root, t, "", &issues)
+	return issues
+}
+
+// Locate はドット区切りのキー（例: "rules.max_lines_per_file"）の値の位置を返す。
+func (d *Document) Locate(key string) (line, column int, ok bool) {
+	node := d.root
+	for _, name := range strings.Split(key, ".") {
+		node = lookup(node, name)
+		if node == nil {
+			return 0, 0, false
+		}
+	}
+	return node.Line, node.Column, true
+}
+
+// lookup はマッピングのキー name（大文字・小文字を区別しない）の値を返す。
+func lookup(node *yaml.Node, name string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, name) {
+			return resolve(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// resolve はエイリアスを参照先のノードに解決する。
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func check(node *yaml.Node, t reflect.Type, key string, issues *[]Issue) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if !expect(node, node.Kind == yaml.MappingNode, key, CodeTypeMapping, "a mapping", issues) {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k := node.Content[i]
+			if k.Value == "<<" {
+				continue
+			}
+			field, ok := fieldByTag(t, k.Value)
+			if !ok {
+				*issues = append(*issues, Issue{
+					Code:    CodeUnknownKey,
+					Key:     join(key, k.Value),
+					Message: fmt.Sprintf("unknown key %q", join(key, k.Value)),
+					Line:    k.Line,
+					Column:  k.Column,
+				})
+				continue
+			}
+			check(resolve(node.Content[i+1]), field.Type, join(key, k.Value), issues)
+		}
+	case reflect.Map:
+		if !expect(node, node.Kind == yaml.MappingNode, key, CodeTypeMapping, "a mapping", issues) {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			check(resolve(node.Content[i+1]), t.Elem(), join(key, node.Content[i].Value), issues)
+		}
+	case reflect.Slice:
+		// 文字列のリストには単一の文字列も指定できる（デコード時に要素 1 つのリストとして扱う）
+		if t.Elem().Kind() == reflect.String && node.Kind == yaml.ScalarNode {
+			check(node, t.Elem(), key, issues)
+			return
+		}
+		if !expect(node, node.Kind == yaml.SequenceNode, key, CodeTypeList, "a list", issues) {
+			return
+		}
+		for i, item := range node.Content {
+			check(resolve(item), t.Elem(), fmt.Sprintf("%s[%d]", key, i), issues)
+		}
+	case reflect.Int, reflect.Int64:
+		expect(node, node.Kind == yaml.ScalarNode && node.Tag == "!!int", key, CodeTypeInteger, "an integer", issues)
+	case reflect.Bool:
+		expect(node, node.Kind == yaml.ScalarNode && node.Tag == "!!bool", key, CodeTypeBoolean, "a boolean", issues)
+	case reflect.String:
+		expect(node, node.Kind == yaml.ScalarNode && node.Tag == "!!str", key, CodeTypeString, "a string", issues)
+	}
+}
+
+// expect は ok でなければ型の不一致を issues に追加し、ok を返す。
+func expect(node *yaml.Node, ok bool, key, code, want string, issues *[]Issue) bool {
+	if !ok {
+		*issues = append(*issues, Issue{
+			Code:    code,
+			Key:     key,
+			Message: fmt.Sprintf("%q must be %s", key, want),
+			Line:    node.Line,
+			Column:  node.Column,
+		})
+	}
+	return ok
+}
+
+// fieldByTag は mapstructure タグが name（大文字・小文字を区別しない）のエクスポートされたフィールドを返す。
+func fieldByTag(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if !f.IsExported() || tag == "" || tag == "-" {
+			continue
+		}
+		if strings.EqualFold(tag, name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func join(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
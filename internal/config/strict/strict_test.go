@@ -0,0 +1,116 @@
+package strict
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRules struct {
+	MaxLines int               `mapstructure:"max_lines"`
+	Severity map[string]string `mapstructure:"severity"`
+	Mode     *string           `mapstructure:"mode"`
+}
+
+type testBudget struct {
+	Path     string `mapstructure:"path"`
+	MaxLines int    `mapstructure:"max_lines"`
+}
+
+type testConfig struct {
+	Rules   testRules    `mapstructure:"rules"`
+	Ignore  []string     `mapstructure:"ignore"`
+	Enabled bool         `mapstructure:"enabled"`
+	Budgets []testBudget `mapstructure:"budgets"`
+	Root    string       `mapstructure:"-"`
+	hidden  string
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []Issue
+	}{
+		{
+			name: "正しい設定",
+			yaml: "rules:\n  max_lines: 10\n  severity:\n    any_rule: off\n  mode: early\nignore: vendor/\nenabled: true\nbudgets:\n  - path: src\n    max_lines: 5\n",
+		},
+		{
+			name: "null の値は未指定として扱う",
+			yaml: "rules:\nignore:\n",
+		},
+		{
+			name: "キーの大文字・小文字は区別しない",
+			yaml: "Rules:\n  MAX_LINES: 10\n",
+		},
+		{
+			name: "エイリアスは参照先で照合する",
+			yaml: "rules:\n  max_lines: &n 10\nbudgets:\n  - path: src\n    max_lines: *n\n",
+		},
+		{
+			name: "未知のキー",
+			yaml: "rules:\n  max_line: 10\nrule: {}\nhidden: x\nRoot: x\n",
+			want: []Issue{
+				{Code: CodeUnknownKey, Key: "rules.max_line", Message: `unknown key "rules.max_line"`, Line: 2, Column: 3},
+				{Code: CodeUnknownKey, Key: "rule", Message: `unknown key "rule"`, Line: 3, Column: 1},
+				{Code: CodeUnknownKey, Key: "hidden", Message: `unknown key "hidden"`, Line: 4, Column: 1},
+				{Code: CodeUnknownKey, Key: "Root", Message: `unknown key "Root"`, Line: 5, Column: 1},
+			},
+		},
+		{
+			name: "型の不一致",
+			yaml: "rules:\n  max_lines: \"10\"\n  severity: [error]\n  mode: 1\nignore: {a: b}\nenabled: yes\nbudgets:\n  - path: 2024\n    max_lines: 1.5\n",
+			want: []Issue{
+				{Code: CodeTypeInteger, Key: "rules.max_lines", Message: `"rules.max_lines" must be an integer`, Line: 2, Column: 14},
+				{Code: CodeTypeMapping, Key: "rules.severity", Message: `"rules.severity" must be a mapping`, Line: 3, Column: 13},
+				{Code: CodeTypeString, Key: "rules.mode", Message: `"rules.mode" must be a string`, Line: 4, Column: 9},
+				{Code: CodeTypeList, Key: "ignore", Message: `"ignore" must be a list`, Line: 5, Column: 9},
+				{Code: CodeTypeBoolean, Key: "enabled", Message: `"enabled" must be a boolean`, Line: 6, Column: 10},
+				{Code: CodeTypeString, Key: "budgets[0].path", Message: `"budgets[0].path" must be a string`, Line: 8, Column: 11},
+				{Code: CodeTypeInteger, Key: "budgets[0].max_lines", Message: `"budgets[0].max_lines" must be an integer`, Line: 9, Column: 16},
+			},
+		},
+		{
+			name: "空のファイル",
+			yaml: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.yaml))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, doc.Check(reflect.TypeFor[testConfig]()))
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	doc, err := Parse([]byte("count_mode: all\nrules:\n  max_lines: 10\n"))
+	require.NoError(t, err)
+
+	line, col, ok := doc.Locate("rules.max_lines")
+	assert.True(t, ok)
+	assert.Equal(t, 3, line)
+	assert.Equal(t, 14, col)
+
+	line, _, ok = doc.Locate("COUNT_MODE")
+	assert.True(t, ok)
+	assert.Equal(t, 1, line)
+
+	_, _, ok = doc.Locate("rules.missing")
+	assert.False(t, ok)
+	_, _, ok = doc.Locate("count_mode.sub")
+	assert.False(t, ok)
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	_, err := Parse([]byte("rules: {}\ncount_mode: all: x\n"))
+	var synErr *SyntaxError
+	require.ErrorAs(t, err, &synErr)
+	assert.Equal(t, 2, synErr.Line)
+	assert.NotContains(t, synErr.Message, "yaml:")
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/ousiassllc/linterly/internal/i18n"
+)
+
+// validate は Config の各フィールドをバリデーションする。
+func validate(cfg *Config) error {
+	var errs []*ConfigError
+
+	if cfg.Rules.MaxLinesPerFile <= 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.max_lines_per_file",
+			Message: `"max_lines_per_file" must be a positive integer`,
+		})
+	}
+	if cfg.Rules.MaxLinesPerDirectory <= 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.max_lines_per_directory",
+			Message: `"max_lines_per_directory" must be a positive integer`,
+		})
+	}
+	if cfg.Rules.WarningThreshold < 0 || cfg.Rules.WarningThreshold > 100 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.warning_threshold",
+			Message: `"warning_threshold" must be between 0 and 100`,
+		})
+	}
+	if cfg.Rules.WarningMarginLines < 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.warning_margin_lines",
+			Message: `"warning_margin_lines" must be 0 or a positive integer`,
+		})
+	}
+	// 未指定（空）の場合は grace として扱う
+	if cfg.Rules.WarningMode != "" && !validWarningMode(cfg.Rules.WarningMode) {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.warning_mode",
+			Message: `"warning_mode" must be "grace" or "early"`,
+		})
+	}
+	errs = append(errs, validateWarnings(cfg.Rules.Warnings)...)
+	errs = append(errs, validateSeverity(cfg.Rules.Severity)...)
+	if cfg.Rules.MaxScriptLinesPerComponent < 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.max_script_lines_per_component",
+			Message: `"max_script_lines_per_component" must be 0 or a positive integer`,
+		})
+	}
+	if cfg.Rules.MaxTemplateLinesPerComponent < 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.max_template_lines_per_component",
+			Message: `"max_template_lines_per_component" must be 0 or a positive integer`,
+		})
+	}
+	if cfg.Rules.MaxLineLength < 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.max_line_length",
+			Message: `"max_line_length" must be 0 or a positive integer`,
+		})
+	}
+	if cfg.Rules.MaxLinesPerFunction < 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.max_lines_per_function",
+			Message: `"max_lines_per_function" must be 0 or a positive integer`,
+		})
+	}
+	if cfg.Rules.MaxDeclarationsPerFile < 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.max_declarations_per_file",
+			Message: `"max_declarations_per_file" must be 0 or a positive integer`,
+		})
+	}
+	if cfg.Rules.MaxLinesPerTestFile < 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.max_lines_per_test_file",
+			Message: `"max_lines_per_test_file" must be 0 or a positive integer`,
+		})
+	}
+	for _, b := range cfg.Budgets {
+		if _, err := path.Match(b.Path, ""); b.Path == "" || b.MaxLines <= 0 || err != nil {
+			errs = append(errs, &ConfigError{
+				Code:    "validation.budgets",
+				Message: fmt.Sprintf(`"budgets" entries require a valid path pattern and a positive max_lines: %q`, b.Path),
+				// path が空の場合もメッセージの引数が欠けないよう、引用符付きで渡す
+				Detail: fmt.Sprintf("%q", b.Path),
+			})
+		}
+	}
+	if cfg.CountMode != CountModeAll && cfg.CountMode != CountModeCodeOnly {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.count_mode",
+			Message: `"count_mode" must be "all" or "code_only"`,
+		})
+	}
+	// 未指定（空）の場合は error として扱う
+	if cfg.FailOn != "" && cfg.FailOn != FailOnError && cfg.FailOn != FailOnWarn && cfg.FailOn != FailOnInfo {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.fail_on",
+			Message: `"fail_on" must be "error", "warn" or "info"`,
+		})
+	}
+	for _, p := range cfg.GeneratedPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			errs = append(errs, &ConfigError{
+				Code:    "validation.generated_patterns",
+				Message: fmt.Sprintf(`"generated_patterns" contains an invalid regular expression: %s`, p),
+				Detail:  p,
+			})
+		}
+	}
+	if !i18n.IsSupportedLanguage(cfg.Language) {
+		errs = append(errs, &ConfigError{
+			Code:    "validation.language",
+			Message: `"language" must be "en" or "ja"`,
+		})
+	}
+
+	if len(errs) > 0 {
+		return &ValidationErrors{Errors: errs}
+	}
+	return nil
+}
+
+func validWarningMode(mode string) bool {
+	return mode == WarningModeGrace || mode == WarningModeEarly
+}
+
+// validateWarnings は rules.warnings のルールごとの設定をバリデーションする。
+// エラーの Detail には問題のあるルール名を設定する。
+func validateWarnings(warnings map[string]RuleWarning) []*ConfigError {
+	rules := make([]string, 0, len(warnings))
+	for rule := range warnings {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+
+	var errs []*ConfigError
+	for _, rule := range rules {
+		w := warnings[rule]
+		if ruleNames[rule] &&
+			(w.Mode == nil || validWarningMode(*w.Mode)) &&
+			(w.Threshold == nil || (*w.Threshold >= 0 && *w.Threshold <= 100)) &&
+			(w.MarginLines == nil || *w.MarginLines >= 0) {
+			continue
+		}
+		errs = append(errs, &ConfigError{
+			Code:    "validation.warnings",
+			Message: fmt.Sprintf(`"warnings" has an unknown rule or an invalid value: %s`, rule),
+			Detail:  rule,
+		})
+	}
+	return errs
+}
+
+// validSeverities は rules.severity に指定できる違反レベル。
+var validSeverities = map[string]bool{
+	SeverityError: true,
+	SeverityWarn:  true,
+	SeverityInfo:  true,
+	SeverityOff:   true,
+}
+
+// validateSeverity は rules.severity のルールごとの違反レベルをバリデーションする。
+// エラーの Detail には問題のあるルール名を設定する。
+func validateSeverity(severity map[string]string) []*ConfigError {
+	rules := make([]string, 0, len(severity))
+	for rule := range severity {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+
+	var errs []*ConfigError
+	for _, rule := range rules {
+		if ruleNames[rule] && validSeverities[severity[rule]] {
+			continue
+		}
+		errs = append(errs, &ConfigError{
+			Code:    "validation.severity",
+			Message: fmt.Sprintf(`"severity" has an unknown rule or a level other than "error", "warn", "info" or "off": %s`, rule),
+			Detail:  rule,
+		})
+	}
+	return errs
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"maps"
+	"slices"
+)
+
+// warn / error の境界の決め方（rules.warning_mode の値）。
+const (
+	// WarningModeGrace は上限を超えてから猶予の範囲内を warn、猶予を超えたら error とする（デフォルト）
+	WarningModeGrace = "grace"
+	// WarningModeEarly は上限の手前から warn とし、上限を超えたら error とする
+	WarningModeEarly = "early"
+)
+
+// rules.warnings・rules.severity でルールごとに設定を上書きできるルール名。
+const (
+	RuleMaxLinesPerFile              = "max_lines_per_file"
+	RuleMaxLinesPerTestFile          = "max_lines_per_test_file"
+	RuleMaxLinesPerDirectory         = "max_lines_per_directory"
+	RuleMaxScriptLinesPerComponent   = "max_script_lines_per_component"
+	RuleMaxTemplateLinesPerComponent = "max_template_lines_per_component"
+	RuleMaxLineLength                = "max_line_length"
+	RuleMaxLinesPerFunction          = "max_lines_per_function"
+	RuleMaxDeclarationsPerFile       = "max_declarations_per_file"
+	RuleBudgets                      = "budgets"
+)
+
+// ruleNames は rules.warnings・rules.severity に指定できるルール名の一覧。
+var ruleNames = map[string]bool{
+	RuleMaxLinesPerFile:              true,
+	RuleMaxLinesPerTestFile:          true,
+	RuleMaxLinesPerDirectory:         true,
+	RuleMaxScriptLinesPerComponent:   true,
+	RuleMaxTemplateLinesPerComponent: true,
+	RuleMaxLineLength:                true,
+	RuleMaxLinesPerFunction:          true,
+	RuleMaxDeclarationsPerFile:       true,
+	RuleBudgets:                      true,
+}
+
+// RuleNames は rules.warnings・rules.severity に指定できるルール名を名前順で返す。
+func RuleNames() []string {
+	return slices.Sorted(maps.Keys(ruleNames))
+}
+
+// ルールの違反レベル（rules.severity の値）。
+const (
+	// SeverityError は上限に応じて warn / error を判定する（デフォルト）
+	SeverityError = "error"
+	// SeverityWarn は違反を最大でも warn とし、終了コードに影響させない
+	SeverityWarn = "warn"
+	// SeverityInfo は違反を info として報告のみ行う
+	SeverityInfo = "info"
+	// SeverityOff はルールのチェックを行わない
+	SeverityOff = "off"
+)
+
+// RuleSeverity はルール rule の違反レベルを返す。rules.severity に指定がなければ SeverityError。
+func (r Rules) RuleSeverity(rule string) string {
+	if s, ok := r.Severity[rule]; ok {
+		return s
+	}
+	return SeverityError
+}
+
+// RuleWarning は rules.warnings のルールごとの警告の設定。nil のフィールドは rules 直下の値を使う。
+type RuleWarning struct {
+	Mode        *string `yaml:"mode" mapstructure:"mode"`
+	Threshold   *int    `yaml:"threshold" mapstructure:"threshold"`
+	MarginLines *int    `yaml:"margin_lines" mapstructure:"margin_lines"`
+}
+
+// WarningPolicy はルールに適用する warn / error の境界の決め方。
+type WarningPolicy struct {
+	Mode string
+	// Threshold は上限に対する割合（%）で表した warn の範囲
+	Threshold int
+	// MarginLines は行数で表した warn の範囲。0 より大きい場合は Threshold より優先する
+	MarginLines int
+}
+
+// Margin は上限 limit に対する warn の範囲の幅を返す。
+func (p WarningPolicy) Margin(limit int) int {
+	if p.MarginLines > 0 {
+		return p.MarginLines
+	}
+	return limit * p.Threshold / 100
+}
+
+// WarningPolicy はルール rule に適用する警告の設定を返す。
+// rules.warnings にルールの指定があればその値で上書きする。threshold のみを指定した場合は、
+// rules 直下の warning_margin_lines を引き継がず割合で判定する。
+func (r Rules) WarningPolicy(rule string) WarningPolicy {
+	p := WarningPolicy{Mode: r.WarningMode, Threshold: r.WarningThreshold, MarginLines: r.WarningMarginLines}
+	if p.Mode == "" {
+		p.Mode = WarningModeGrace
+	}
+	w, ok := r.Warnings[rule]
+	if !ok {
+		return p
+	}
+	if w.Mode != nil {
+		p.Mode = *w.Mode
+	}
+	if w.Threshold != nil {
+		p.Threshold = *w.Threshold
+		p.MarginLines = 0
+	}
+	if w.MarginLines != nil {
+		p.MarginLines = *w.MarginLines
+	}
+	return p
+}
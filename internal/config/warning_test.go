@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Warnings(t *testing.T) {
+	cfg, err := Load("testdata/valid_warnings.yml")
+	require.NoError(t, err)
+
+	// rules 直下の設定（warning_margin_lines が warning_threshold より優先）
+	file := cfg.Rules.WarningPolicy(RuleMaxLinesPerFile)
+	assert.Equal(t, WarningPolicy{Mode: WarningModeGrace, Threshold: 10, MarginLines: 20}, file)
+	assert.Equal(t, 20, file.Margin(300))
+
+	// threshold のみの上書きは warning_margin_lines を引き継がない
+	dir := cfg.Rules.WarningPolicy(RuleMaxLinesPerDirectory)
+	assert.Equal(t, WarningPolicy{Mode: WarningModeEarly, Threshold: 10}, dir)
+	assert.Equal(t, 200, dir.Margin(2000))
+
+	fn := cfg.Rules.WarningPolicy(RuleMaxLinesPerFunction)
+	assert.Equal(t, WarningPolicy{Mode: WarningModeGrace, Threshold: 10, MarginLines: 5}, fn)
+}
+
+func TestLoad_WarningsDefault(t *testing.T) {
+	cfg, err := Load("testdata/valid_minimal.yml")
+	require.NoError(t, err)
+	assert.Equal(t, WarningPolicy{Mode: WarningModeGrace, Threshold: DefaultWarningThreshold},
+		cfg.Rules.WarningPolicy(RuleMaxLinesPerFile))
+}
+
+func TestLoad_InvalidWarnings(t *testing.T) {
+	_, err := Load("testdata/invalid_warnings.yml")
+	require.Error(t, err)
+
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	assert.Equal(t, []string{
+		"validation.warning_margin_lines",
+		"validation.warning_mode",
+		"validation.warnings",
+		"validation.warnings",
+	}, codeList(valErrs))
+	// ルール名の順に報告する
+	assert.Equal(t, "max_lines_per_file", valErrs.Errors[2].Detail)
+	assert.Equal(t, "max_lines_per_module", valErrs.Errors[3].Detail)
+}
+
+func TestLoad_Severity(t *testing.T) {
+	cfg, err := Load("testdata/valid_severity.yml")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityInfo, cfg.Rules.RuleSeverity(RuleMaxLinesPerDirectory))
+	assert.Equal(t, SeverityOff, cfg.Rules.RuleSeverity(RuleMaxLineLength))
+	assert.Equal(t, SeverityWarn, cfg.Rules.RuleSeverity(RuleBudgets))
+	// 未指定のルールは error
+	assert.Equal(t, SeverityError, cfg.Rules.RuleSeverity(RuleMaxLinesPerFile))
+
+	_, err = Load("testdata/invalid_severity.yml")
+	require.Error(t, err)
+	var valErrs *ValidationErrors
+	require.True(t, errors.As(err, &valErrs))
+	require.Len(t, valErrs.Errors, 2)
+	assert.Equal(t, "validation.severity", valErrs.Errors[0].Code)
+	assert.Equal(t, "max_lines_per_file", valErrs.Errors[0].Detail)
+	assert.Equal(t, "max_lines_per_module", valErrs.Errors[1].Detail)
+}
@@ -1,60 +1,149 @@
 package counter
 
 import (
-	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
-	"strings"
 	"sync"
 
 	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/counter/funcs"
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+	"github.com/ousiassllc/linterly/internal/lines"
 )
 
-// maxScanBufSize はスキャナーのバッファ上限（1MB）。
-const maxScanBufSize = 1024 * 1024
+// Options はカウント時の設定。
+type Options struct {
+	CountMode     string // config.CountModeAll / config.CountModeCodeOnly
+	MaxLineLength int    // 0 より大きい場合、表示幅がこの値を超える行を LongLines に記録する
+	// MaxLinesPerFunction が 0 より大きい場合、関数を検出して Functions に記録する
+	MaxLinesPerFunction int
+	// MaxDeclarationsPerFile が 0 より大きい場合、トップレベルの宣言を検出して Declarations に記録する
+	MaxDeclarationsPerFile int
+}
+
+// NewOptions は設定からカウント時の Options を構築する。
+func NewOptions(cfg *config.Config) Options {
+	return Options{
+		CountMode:              cfg.CountMode,
+		MaxLineLength:          cfg.Rules.MaxLineLength,
+		MaxLinesPerFunction:    cfg.Rules.MaxLinesPerFunction,
+		MaxDeclarationsPerFile: cfg.Rules.MaxDeclarationsPerFile,
+	}
+}
 
 // LineCount はファイルの行数カウント結果。
 type LineCount struct {
 	Path       string
-	TotalLines int // 全行数
-	CodeLines  int // コード行数（コメント・空行除外）
+	TotalLines int       // 全行数
+	CodeLines  int       // コード行数（コメント・空行除外）
+	Sections   []Section // セクションごとの行数（Vue/Svelte/Astro 等のみ）
+	// LongestLine は最長の行の文字数（rune 数、改行文字を除く）
+	LongestLine int
+	// MaxLineWidth は最も幅の広い行の表示幅（タブ展開・全角文字を考慮）。
+	// LongLines は表示幅が Options.MaxLineLength を超える行の行番号（1 始まり）。
+	// いずれも Options.MaxLineLength が 0 より大きい場合のみ設定する。
+	MaxLineWidth int
+	LongLines    []int
+	// Functions は検出した関数ごとの行数。Options.MaxLinesPerFunction が 0 より大きく、
+	// 関数の検出に対応した言語の場合のみ設定する
+	Functions []funcs.Function
+	// Declarations はトップレベルの宣言。Options.MaxDeclarationsPerFile が 0 より大きく、
+	// 宣言の検出に対応した言語の場合のみ設定する（宣言がなければ空のスライス、対象外なら nil）
+	Declarations []funcs.Declaration
+	// Test は test_patterns にマッチするテストファイルかどうか（走査結果から設定する）
+	Test bool
+}
+
+// Section はファイル内のセクションごとの行数。
+// 単一ファイルコンポーネントの <template>/<script>/<style> ブロック等に対応する。
+type Section struct {
+	Name       string // セクション名（例: "script", "template", "style"）
+	TotalLines int    // 全行数
+	CodeLines  int    // コード行数（コメント・空行除外）
 }
 
 // CountFile は指定ファイルの行数をカウントする。
-func CountFile(path string, mode string) (*LineCount, error) {
+func CountFile(path string, opts Options) (*LineCount, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
 
-	result := &LineCount{Path: path}
+	// BOM 付きのファイルは UTF-8 に変換し、BOM を取り除いてからカウントする
+	r := lines.Decode(f)
+	// 行の表示幅はカウントする行（変換後のテキスト）ごとに計測する。
+	// Notebook のように行がファイルの行と異なる形式では、カウントする行の通し番号を行番号とする
+	var widths *lines.WidthChecker
+	if opts.MaxLineLength > 0 {
+		widths = lines.NewWidthChecker(opts.MaxLineLength)
+	}
 
-	if mode == config.CountModeCodeOnly {
-		lang := DetectLanguage(path)
-		total, code, err := countCodeOnly(f, lang)
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", path, err)
-		}
-		result.TotalLines = total
-		result.CodeLines = code
-	} else {
-		total, err := countAll(f)
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", path, err)
-		}
-		result.TotalLines = total
-		result.CodeLines = total
+	lang := DetectLanguage(path)
+	mode := opts.CountMode
+
+	// 関数・宣言の検出はソース全体を必要とするため、カウントと並行してバッファに保持する
+	var src *bytes.Buffer
+	if (opts.MaxLinesPerFunction > 0 || opts.MaxDeclarationsPerFile > 0) && lang != nil && lang.Functions != funcs.StyleNone {
+		src = &bytes.Buffer{}
+		r = io.TeeReader(r, src)
+	}
+
+	var result *LineCount
+	switch {
+	case lang != nil && lang.SectionCounter != nil:
+		result, err = lang.SectionCounter(r, lang, widths)
+	case mode == config.CountModeCodeOnly:
+		result, err = countCodeOnly(r, lang, widths)
+	default:
+		result, err = countAll(r, widths)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
 	}
 
+	result.Path = path
+	if mode != config.CountModeCodeOnly {
+		result.CodeLines = result.TotalLines
+	}
+	if widths != nil {
+		result.MaxLineWidth = widths.Widest()
+		result.LongLines = widths.LongLines()
+	}
+	if src != nil {
+		detectFunctions(result, lang, src.Bytes(), opts)
+	}
 	return result, nil
 }
 
+// detectFunctions はソースから関数・トップレベルの宣言を検出して result に設定する。
+// 構文エラー等で解析できないファイルはこれらのチェックの対象外とする（行数のチェックは行う）。
+func detectFunctions(result *LineCount, lang *Language, src []byte, opts Options) {
+	syn := *lang.syntax()
+	if opts.MaxLinesPerFunction > 0 {
+		if fns, err := funcs.Detect(lang.Functions, src, syn); err == nil {
+			result.Functions = fns
+		}
+	}
+	if opts.MaxDeclarationsPerFile > 0 {
+		if decls, err := funcs.Declarations(lang.Functions, src, syn); err == nil {
+			result.Declarations = decls
+		}
+	}
+}
+
 // CountFiles は複数ファイルの行数を並行してカウントする。
 // 返されるスライスは入力の files スライスと同じインデックス順序を保証する。
 // つまり results[i] は files[i] のカウント結果に対応する。
-func CountFiles(files []string, mode string) ([]LineCount, error) {
+func CountFiles(files []string, opts Options) ([]LineCount, error) {
+	return CountFilesFunc(files, func(int) Options { return opts })
+}
+
+// CountFilesFunc は CountFiles と同様に複数ファイルの行数を並行してカウントする。
+// files[i] は optsFor(i) の Options でカウントする（ファイルごとに設定が異なる場合に使う）。
+func CountFilesFunc(files []string, optsFor func(i int) Options) ([]LineCount, error) {
 	type countResult struct {
 		lineCount LineCount
 		err       error
@@ -69,7 +158,7 @@ func CountFiles(files []string, mode string) ([]LineCount, error) {
 		wg.Add(1)
 		go func(idx int, p string) {
 			defer wg.Done()
-			lc, err := CountFile(p, mode)
+			lc, err := CountFile(p, optsFor(idx))
 			if err != nil {
 				ch <- countResult{err: err, index: idx}
 				return
@@ -101,122 +190,38 @@ func CountFiles(files []string, mode string) ([]LineCount, error) {
 	return results, nil
 }
 
-// countAll はファイルの全行数をカウントする。
-func countAll(r io.Reader) (int, error) {
-	scanner := bufio.NewScanner(r)
-	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxScanBufSize)
-	count := 0
-	for scanner.Scan() {
-		count++
-	}
-	if err := scanner.Err(); err != nil {
-		return 0, err
-	}
-	return count, nil
-}
-
-// countCodeOnly はコード行数を計算する（コメント・空行除外）。
-func countCodeOnly(r io.Reader, lang *Language) (total int, code int, err error) {
-	scanner := bufio.NewScanner(r)
-	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxScanBufSize)
-	inBlock := false
-	// Python docstring のためのトラッカー
-	isPython := lang != nil && lang.Name == "Python"
-
-	for scanner.Scan() {
+// countAll はファイルの全行数をカウントする。widths が nil でない場合は各行の表示幅も計測する。
+func countAll(r io.Reader, widths *lines.WidthChecker) (*LineCount, error) {
+	lr := lines.NewReader(r)
+	total := 0
+	for lr.Scan() {
 		total++
-		line := scanner.Text()
-		trimmed := strings.TrimSpace(line)
-
-		// 空行チェック
-		if trimmed == "" {
-			continue
-		}
-
-		// 対応言語がない場合、すべてコード行として扱う
-		if lang == nil {
-			code++
-			continue
-		}
-
-		// ブロックコメント内にいる場合
-		if inBlock {
-			if containsBlockEnd(trimmed, lang, isPython) {
-				inBlock = false
-			}
-			continue
-		}
-
-		// ブロックコメント開始チェック
-		if lang.BlockCommentStart != "" && containsBlockStart(trimmed, lang, isPython) {
-			// 同じ行で開始・終了する場合
-			if sameLineBlockComment(trimmed, lang, isPython) {
-				continue
-			}
-			inBlock = true
-			continue
-		}
-
-		// 行コメントチェック
-		if isLineComment(trimmed, lang) {
-			continue
-		}
-
-		code++
+		widths.Add(lr.Text())
 	}
-
-	if err := scanner.Err(); err != nil {
-		return 0, 0, err
-	}
-	return total, code, nil
-}
-
-// isLineComment は行が行コメントであるかを判定する。
-func isLineComment(trimmed string, lang *Language) bool {
-	for _, prefix := range lang.LineCommentStart {
-		if strings.HasPrefix(trimmed, prefix) {
-			return true
-		}
-	}
-	return false
-}
-
-// containsBlockStart は行がブロックコメント開始を含むかを判定する。
-func containsBlockStart(trimmed string, lang *Language, isPython bool) bool {
-	if isPython {
-		return strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, `'''`)
-	}
-	return strings.HasPrefix(trimmed, lang.BlockCommentStart)
-}
-
-// containsBlockEnd は行がブロックコメント終了を含むかを判定する。
-func containsBlockEnd(trimmed string, lang *Language, isPython bool) bool {
-	if isPython {
-		// 開始行と同じ行の場合は sameLineBlockComment で処理済み
-		// ここでは終了行のみチェック
-		return strings.HasSuffix(trimmed, `"""`) || strings.HasSuffix(trimmed, `'''`)
+	if err := lr.Err(); err != nil {
+		return nil, err
 	}
-	return strings.Contains(trimmed, lang.BlockCommentEnd)
+	return &LineCount{TotalLines: total, CodeLines: total, LongestLine: lr.Longest()}, nil
 }
 
-// sameLineBlockComment は同じ行でブロックコメントが開始・終了するかを判定する。
-func sameLineBlockComment(trimmed string, lang *Language, isPython bool) bool {
-	if isPython {
-		// """...""" or '''...'''
-		for _, delim := range []string{`"""`, `'''`} {
-			if strings.HasPrefix(trimmed, delim) {
-				rest := trimmed[len(delim):]
-				if strings.Contains(rest, delim) {
-					return true
-				}
-			}
+// countCodeOnly はコード行数を計算する（コメント・空行除外）。widths は countAll と同じ。
+func countCodeOnly(r io.Reader, lang *Language, widths *lines.WidthChecker) (*LineCount, error) {
+	lr := lines.NewReader(r)
+	classifier := syntax.NewClassifier(lang.syntax())
+
+	result := &LineCount{}
+	for lr.Scan() {
+		result.TotalLines++
+		line := lr.Text()
+		widths.Add(line)
+		if classifier.IsCode(line) {
+			result.CodeLines++
 		}
-		return false
 	}
 
-	if strings.HasPrefix(trimmed, lang.BlockCommentStart) {
-		rest := trimmed[len(lang.BlockCommentStart):]
-		return strings.Contains(rest, lang.BlockCommentEnd)
+	if err := lr.Err(); err != nil {
+		return nil, err
 	}
-	return false
+	result.LongestLine = lr.Longest()
+	return result, nil
 }
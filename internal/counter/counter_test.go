@@ -14,14 +14,14 @@ import (
 )
 
 func TestCountFile_AllMode_Go(t *testing.T) {
-	lc, err := CountFile("testdata/sample.go", config.CountModeAll)
+	lc, err := CountFile("testdata/sample.go", Options{CountMode: config.CountModeAll})
 	require.NoError(t, err)
 	assert.Equal(t, 13, lc.TotalLines)
 	assert.Equal(t, 13, lc.CodeLines) // all モードでは同じ
 }
 
 func TestCountFile_CodeOnly_Go(t *testing.T) {
-	lc, err := CountFile("testdata/sample.go", config.CountModeCodeOnly)
+	lc, err := CountFile("testdata/sample.go", Options{CountMode: config.CountModeCodeOnly})
 	require.NoError(t, err)
 	assert.Equal(t, 13, lc.TotalLines)
 	// 空行(3) + 行コメント(1) + ブロックコメント(4) = 8 非コード行
@@ -30,7 +30,7 @@ func TestCountFile_CodeOnly_Go(t *testing.T) {
 }
 
 func TestCountFile_CodeOnly_Python(t *testing.T) {
-	lc, err := CountFile("testdata/sample.py", config.CountModeCodeOnly)
+	lc, err := CountFile("testdata/sample.py", Options{CountMode: config.CountModeCodeOnly})
 	require.NoError(t, err)
 	assert.Equal(t, 15, lc.TotalLines)
 	// 空行(4) + 行コメント(2) + ブロックコメント(4行) + docstring(1行) = 11 非コード行
@@ -39,7 +39,7 @@ func TestCountFile_CodeOnly_Python(t *testing.T) {
 }
 
 func TestCountFile_CodeOnly_HTML(t *testing.T) {
-	lc, err := CountFile("testdata/sample.html", config.CountModeCodeOnly)
+	lc, err := CountFile("testdata/sample.html", Options{CountMode: config.CountModeCodeOnly})
 	require.NoError(t, err)
 	assert.Equal(t, 10, lc.TotalLines)
 	// ブロックコメント(5行) + 空行(0) = 5 非コード行
@@ -48,7 +48,7 @@ func TestCountFile_CodeOnly_HTML(t *testing.T) {
 }
 
 func TestCountFile_CodeOnly_CSS(t *testing.T) {
-	lc, err := CountFile("testdata/sample.css", config.CountModeCodeOnly)
+	lc, err := CountFile("testdata/sample.css", Options{CountMode: config.CountModeCodeOnly})
 	require.NoError(t, err)
 	assert.Equal(t, 5, lc.TotalLines)
 	// ブロックコメント(1行) = 1 非コード行
@@ -57,7 +57,7 @@ func TestCountFile_CodeOnly_CSS(t *testing.T) {
 }
 
 func TestCountFile_CodeOnly_Shell(t *testing.T) {
-	lc, err := CountFile("testdata/sample.sh", config.CountModeCodeOnly)
+	lc, err := CountFile("testdata/sample.sh", Options{CountMode: config.CountModeCodeOnly})
 	require.NoError(t, err)
 	assert.Equal(t, 7, lc.TotalLines)
 	// 空行(2) + 行コメント(3: shebang含む) = 5 非コード行
@@ -66,21 +66,21 @@ func TestCountFile_CodeOnly_Shell(t *testing.T) {
 }
 
 func TestCountFile_EmptyFile(t *testing.T) {
-	lc, err := CountFile("testdata/empty.txt", config.CountModeAll)
+	lc, err := CountFile("testdata/empty.txt", Options{CountMode: config.CountModeAll})
 	require.NoError(t, err)
 	assert.Equal(t, 0, lc.TotalLines)
 	assert.Equal(t, 0, lc.CodeLines)
 }
 
 func TestCountFile_UnknownLanguage_CodeOnly(t *testing.T) {
-	lc, err := CountFile("testdata/unknown.xyz", config.CountModeCodeOnly)
+	lc, err := CountFile("testdata/unknown.xyz", Options{CountMode: config.CountModeCodeOnly})
 	require.NoError(t, err)
 	assert.Equal(t, 3, lc.TotalLines)
 	assert.Equal(t, 3, lc.CodeLines) // 対応言語なし → 全行コード行
 }
 
 func TestCountFile_NonExistent(t *testing.T) {
-	_, err := CountFile("testdata/nonexistent.go", config.CountModeAll)
+	_, err := CountFile("testdata/nonexistent.go", Options{CountMode: config.CountModeAll})
 	assert.Error(t, err)
 }
 
@@ -91,7 +91,7 @@ func TestCountFiles_Parallel(t *testing.T) {
 		"testdata/sample.sh",
 	}
 
-	results, err := CountFiles(files, config.CountModeAll)
+	results, err := CountFiles(files, Options{CountMode: config.CountModeAll})
 	require.NoError(t, err)
 	assert.Len(t, results, 3)
 
@@ -107,7 +107,7 @@ func TestCountFiles_WithError(t *testing.T) {
 		"testdata/nonexistent.go",
 	}
 
-	_, err := CountFiles(files, config.CountModeAll)
+	_, err := CountFiles(files, Options{CountMode: config.CountModeAll})
 	assert.Error(t, err)
 }
 
@@ -116,13 +116,13 @@ func TestCountFile_NoTrailingNewline(t *testing.T) {
 	path := filepath.Join(tmpDir, "no_newline.go")
 	require.NoError(t, os.WriteFile(path, []byte("package main\nfunc main() {}"), 0644))
 
-	lc, err := CountFile(path, config.CountModeAll)
+	lc, err := CountFile(path, Options{CountMode: config.CountModeAll})
 	require.NoError(t, err)
 	assert.Equal(t, 2, lc.TotalLines)
 }
 
 func TestCountFile_LargeLine_AllMode(t *testing.T) {
-	// bufio.MaxScanTokenSize(64KB) を超えるが maxScanBufSize(1MB) 以内の行は正常にカウントできる
+	// bufio.MaxScanTokenSize(64KB) を超える行も正常にカウントできる
 	tmpDir := t.TempDir()
 	path := filepath.Join(tmpDir, "large_line.txt")
 
@@ -130,62 +130,159 @@ func TestCountFile_LargeLine_AllMode(t *testing.T) {
 	data := append(bytes.Repeat([]byte("a"), bufio.MaxScanTokenSize+1), []byte("\nhello\n")...)
 	require.NoError(t, os.WriteFile(path, data, 0644))
 
-	lc, err := CountFile(path, config.CountModeAll)
+	lc, err := CountFile(path, Options{CountMode: config.CountModeAll})
 	require.NoError(t, err)
 	assert.Equal(t, 2, lc.TotalLines)
 }
 
 func TestCountFile_LargeLine_CodeOnlyMode(t *testing.T) {
-	// bufio.MaxScanTokenSize(64KB) を超えるが maxScanBufSize(1MB) 以内の行は正常にカウントできる
+	// bufio.MaxScanTokenSize(64KB) を超える行も正常にカウントできる
 	tmpDir := t.TempDir()
 	path := filepath.Join(tmpDir, "large_line.go")
 
 	data := append(bytes.Repeat([]byte("a"), bufio.MaxScanTokenSize+1), []byte("\npackage main\n")...)
 	require.NoError(t, os.WriteFile(path, data, 0644))
 
-	lc, err := CountFile(path, config.CountModeCodeOnly)
+	lc, err := CountFile(path, Options{CountMode: config.CountModeCodeOnly})
 	require.NoError(t, err)
 	assert.Equal(t, 2, lc.TotalLines)
 }
 
-func TestCountFile_ScannerError_AllMode(t *testing.T) {
-	// maxScanBufSize(1MB) を超える改行なしデータでエラーを返し、ファイルパスが含まれることを確認
+func TestCountFile_HugeLine_AllMode(t *testing.T) {
+	// 1MB を超える改行なしの行（minify されたファイル等）でもエラーにならずカウントできる
 	tmpDir := t.TempDir()
 	path := filepath.Join(tmpDir, "huge_line.txt")
 
-	data := bytes.Repeat([]byte("a"), maxScanBufSize+1)
+	data := bytes.Repeat([]byte("a"), 4*1024*1024+1)
 	require.NoError(t, os.WriteFile(path, data, 0644))
 
-	_, err := CountFile(path, config.CountModeAll)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), path)
+	lc, err := CountFile(path, Options{CountMode: config.CountModeAll})
+	require.NoError(t, err)
+	assert.Equal(t, 1, lc.TotalLines)
+	assert.Equal(t, len(data), lc.LongestLine)
 }
 
-func TestCountFile_ScannerError_CodeOnlyMode(t *testing.T) {
-	// maxScanBufSize(1MB) を超える改行なしデータでエラーを返し、ファイルパスが含まれることを確認
+func TestCountFile_HugeLine_CodeOnlyMode(t *testing.T) {
 	tmpDir := t.TempDir()
-	path := filepath.Join(tmpDir, "huge_line.go")
+	path := filepath.Join(tmpDir, "huge_line.js")
 
-	data := bytes.Repeat([]byte("a"), maxScanBufSize+1)
+	data := append([]byte("// header\n"), bytes.Repeat([]byte("a"), 4*1024*1024+1)...)
 	require.NoError(t, os.WriteFile(path, data, 0644))
 
-	_, err := CountFile(path, config.CountModeCodeOnly)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), path)
+	lc, err := CountFile(path, Options{CountMode: config.CountModeCodeOnly})
+	require.NoError(t, err)
+	assert.Equal(t, 2, lc.TotalLines)
+	assert.Equal(t, 1, lc.CodeLines)
+	assert.Equal(t, 4*1024*1024+1, lc.LongestLine)
+}
+
+func TestCountFile_LineEndings(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		total int
+	}{
+		{"LF", "a\nb\nc\n", 3},
+		{"CRLF", "a\r\nb\r\nc\r\n", 3},
+		{"CR のみ（旧 Mac）", "a\rb\rc\r", 3},
+		{"混在", "a\nb\r\nc\rd", 4},
+		{"空行の CR", "a\r\r\nb", 3},
+		{"末尾改行なし", "a\nb", 2},
+		{"空ファイル", "", 0},
+		{"改行のみ", "\n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "file.txt")
+			require.NoError(t, os.WriteFile(path, []byte(tt.src), 0644))
+
+			for _, mode := range []string{config.CountModeAll, config.CountModeCodeOnly} {
+				lc, err := CountFile(path, Options{CountMode: mode})
+				require.NoError(t, err)
+				assert.Equal(t, tt.total, lc.TotalLines, mode)
+			}
+		})
+	}
 }
 
 func TestCountAll_FromReader(t *testing.T) {
 	r := strings.NewReader("line1\nline2\nline3\n")
-	count, err := countAll(r)
+	lc, err := countAll(r, nil)
 	require.NoError(t, err)
-	assert.Equal(t, 3, count)
+	assert.Equal(t, 3, lc.TotalLines)
 }
 
 func TestCountCodeOnly_FromReader(t *testing.T) {
 	r := strings.NewReader("package main\n\n// comment\nfunc main() {}\n")
 	lang := DetectLanguage("example.go")
-	total, code, err := countCodeOnly(r, lang)
+	lc, err := countCodeOnly(r, lang, nil)
 	require.NoError(t, err)
-	assert.Equal(t, 4, total)
-	assert.Equal(t, 2, code)
+	assert.Equal(t, 4, lc.TotalLines)
+	assert.Equal(t, 2, lc.CodeLines)
+}
+
+func TestCountCodeOnly_Languages(t *testing.T) {
+	// 各言語について「行コメント・ブロックコメント・空行・コード行」を含むソースで
+	// コメント構文が正しく認識されることを確認する
+	tests := []struct {
+		name  string
+		ext   string
+		src   string
+		total int
+		code  int
+	}{
+		{"Swift", ".swift", "// c\n/* a\nb */\n\nlet x = 1\n", 5, 1},
+		{"Scala", ".scala", "// c\n/* a\nb */\n\nval x = 1\n", 5, 1},
+		{"Groovy", ".groovy", "// c\n/* a */\n\ndef x = 1\n", 4, 1},
+		{"C#", ".cs", "// c\n/* a\nb */\n\nvar x = 1;\n", 5, 1},
+		{"Objective-C", ".m", "// c\n/* a */\nint x = 1;\n", 3, 1},
+		{"Dart", ".dart", "// c\n/// doc\n/* a */\nvar x = 1;\n", 4, 1},
+		{"PHP", ".php", "<?php\n// c\n/* a\nb */\n#[Attr]\n$x = 1;\n", 6, 3},
+		{"Solidity", ".sol", "// c\n/* a */\nuint x = 1;\n", 3, 1},
+		{"Protocol Buffers", ".proto", "// c\n/* a */\nsyntax = \"proto3\";\n", 3, 1},
+		{"Zig", ".zig", "// c\n/// doc\nconst x = 1;\n", 3, 1},
+		{"Crystal", ".cr", "# c\n\nx = 1\n", 3, 1},
+		{"PowerShell", ".ps1", "# c\n<#\nhelp\n#>\n$x = 1\n", 5, 1},
+		{"PowerShell_SameLine", ".ps1", "<# help #>\n$x = 1\n", 2, 1},
+		{"Perl", ".pl", "# c\n=pod\ndoc\n=cut\nmy $x = 1;\n", 5, 1},
+		{"R", ".r", "# c\nx <- 1\n", 2, 1},
+		{"Julia", ".jl", "# c\n#=\nblock\n=#\nx = 1\n", 5, 1},
+		{"Nim", ".nim", "# c\n#[\nblock\n]#\nvar x = 1\n", 5, 1},
+		{"Elixir", ".ex", "# c\n\nx = 1\n", 3, 1},
+		{"Erlang", ".erl", "% c\n%% doc\nX = 1.\n", 3, 1},
+		{"Lua", ".lua", "-- c\n--[[\nblock\n]]\nlocal x = 1\n", 5, 1},
+		{"Lua_SameLine", ".lua", "--[[ block ]]\nlocal x = 1\n", 2, 1},
+		{"SQL", ".sql", "-- c\n/* a\nb */\nSELECT 1;\n", 4, 1},
+		{"Haskell", ".hs", "-- c\n{-\nblock\n-}\nx = 1\n", 5, 1},
+		{"Elm", ".elm", "-- c\n{- a -}\nx = 1\n", 3, 1},
+		{"OCaml", ".ml", "(* a\nb *)\nlet x = 1\n", 3, 1},
+		{"F#", ".fs", "// c\n(* a *)\nlet x = 1\n", 3, 1},
+		{"Clojure", ".clj", "; c\n;; doc\n(def x 1)\n", 3, 1},
+		{"Lisp", ".lisp", "; c\n;;; doc\n(defvar x 1)\n", 3, 1},
+		{"Visual Basic", ".vb", "' c\nDim x = 1\n", 2, 1},
+		{"Fortran", ".f90", "! c\nx = 1\n", 2, 1},
+		{"Assembly", ".asm", "; c\nmov eax, 1\n", 2, 1},
+		{"Makefile", ".mk", "# c\nall: build\n", 2, 1},
+		{"CMake", ".cmake", "# c\n#[[\nblock\n]]\nset(X 1)\n", 5, 1},
+		{"Vue", ".vue", "<!-- c -->\n<template>\n</template>\n", 3, 2},
+		{"Svelte", ".svelte", "<!--\nc\n-->\n<h1>hi</h1>\n", 4, 1},
+		{"Less", ".less", "// c\n/* a */\n.a { color: red; }\n", 3, 1},
+		{"YAML", ".yml", "# c\n\nkey: value\n", 3, 1},
+		{"TOML", ".toml", "# c\nkey = 1\n", 2, 1},
+		{"INI", ".ini", "; c\n# c\nkey=1\n", 3, 1},
+		{"HCL", ".tf", "# c\n// c\n/* a */\nx = 1\n", 4, 1},
+		{"GraphQL", ".graphql", "# c\ntype Query { x: Int }\n", 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := DetectLanguage("file" + tt.ext)
+			require.NotNil(t, lang, "expected language for %s", tt.ext)
+			lc, err := countCodeOnly(strings.NewReader(tt.src), lang, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.total, lc.TotalLines)
+			assert.Equal(t, tt.code, lc.CodeLines)
+		})
+	}
 }
@@ -0,0 +1,20 @@
+package counter
+
+import (
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountFile_UTF16(t *testing.T) {
+	// UTF-16LE（BOM 付き）のファイルを UTF-8 に変換してカウントする
+	lc, err := CountFile("testdata/utf16le.cs", Options{CountMode: config.CountModeCodeOnly, MaxLineLength: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 4, lc.TotalLines)
+	assert.Equal(t, 2, lc.CodeLines)
+	assert.Equal(t, len("using System;"), lc.LongestLine)
+	// "// コメント" は 3 + 4*2 = 11 桁、"using System;" は 13 桁
+	assert.Equal(t, []int{1, 2}, lc.LongLines)
+}
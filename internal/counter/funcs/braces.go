@@ -0,0 +1,128 @@
+package funcs
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+)
+
+// braceScope は波括弧のブロック1つ分。
+type braceScope struct {
+	fn    bool   // 関数本体のブロック
+	name  string // 関数名
+	start int    // 関数の開始行（1 始まり）
+	// transparent は namespace のように、内側の宣言をトップレベルとみなすブロック
+	transparent bool
+}
+
+// braceHeader は直前の `;` `{` `}` から `{` までのテキスト（ヘッダー）を保持する。
+// 関数の開始行を求めるため、各バイトの行番号も記録する。
+type braceHeader struct {
+	text  []byte
+	lines []int
+	// starts は () [] の外側で始まった行の先頭位置。
+	// セミコロンのない言語で前の文がヘッダーに混ざらないよう、最後の行からヘッダーを解析する
+	starts []int
+	depth  int
+}
+
+func (h *braceHeader) reset() {
+	h.text, h.lines, h.starts, h.depth = h.text[:0], h.lines[:0], h.starts[:0], 0
+}
+
+func (h *braceHeader) add(c byte, line int) {
+	switch c {
+	case '(', '[':
+		h.depth++
+	case ')', ']':
+		h.depth = max(h.depth-1, 0)
+	}
+	h.text = append(h.text, c)
+	h.lines = append(h.lines, line)
+}
+
+// newLine は行の区切りを記録する。
+func (h *braceHeader) newLine(line int) {
+	if len(h.text) == 0 {
+		return
+	}
+	h.add(' ', line)
+	if h.depth == 0 {
+		h.starts = append(h.starts, len(h.text))
+	}
+}
+
+// parse はヘッダーが関数であれば、関数名と開始行を返す。
+// 最後の行から順に、前の行を含めたヘッダーを解析する（Allman スタイルや複数行のシグネチャに対応する）。
+func (h *braceHeader) parse(syn syntax.Syntax) (string, int, bool) {
+	for i := len(h.starts) - 1; i >= -1; i-- {
+		offset := 0
+		if i >= 0 {
+			offset = h.starts[i]
+		}
+		text := string(h.text[offset:])
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if name, pos, ok := parseBraceHeader(text, syn); ok {
+			return name, h.lines[offset+pos], true
+		}
+	}
+	return "", 0, false
+}
+
+// detectBraces は波括弧の対応から関数と、トップレベルの宣言（関数・型）を検出する。
+// `{` の直前のヘッダーが関数の宣言・無名関数の形をしていれば、対応する `}` までを関数とみなす。
+func detectBraces(lines []line, syn syntax.Syntax) ([]Function, []Declaration) {
+	var (
+		fns   []Function
+		decls []Declaration
+		stack []braceScope
+		hdr   braceHeader
+	)
+	topLevel := func() bool {
+		return !slices.ContainsFunc(stack, func(s braceScope) bool { return !s.transparent })
+	}
+	for i, l := range lines {
+		ln := i + 1
+		for j := 0; j < len(l.text); j++ {
+			switch c := l.text[j]; {
+			case c == '{':
+				scope := braceScope{}
+				if name, start, ok := hdr.parse(syn); ok {
+					scope = braceScope{fn: true, name: name, start: start}
+					if topLevel() {
+						decls = append(decls, Declaration{Kind: KindFunction, Name: name, Line: start})
+					}
+				} else if d, ok := hdr.declaration(); ok {
+					scope.transparent = transparentKeywords[d.Kind]
+					if topLevel() && !scope.transparent && !extensionKeywords[d.Kind] {
+						decls = append(decls, d)
+					}
+				}
+				stack = append(stack, scope)
+				hdr.reset()
+			case c == '}':
+				if n := len(stack); n > 0 {
+					if scope := stack[n-1]; scope.fn {
+						fns = append(fns, newFunction(scope.name, scope.start, ln, lines))
+					}
+					stack = stack[:n-1]
+				}
+				hdr.reset()
+			case c == ';' && hdr.depth == 0:
+				// `type Name = ...;` のようにブロックを持たない型の宣言
+				if d, ok := hdr.declaration(); ok && aliasKeywords[d.Kind] && topLevel() {
+					decls = append(decls, d)
+				}
+				hdr.reset()
+			default:
+				hdr.add(c, ln)
+			}
+		}
+		hdr.newLine(ln)
+	}
+	sortByLine(fns)
+	return fns, decls
+}
@@ -0,0 +1,224 @@
+package funcs
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+)
+
+// anonymousName は名前のない関数（無名関数・ラムダ式）の表示名。
+const anonymousName = "(anonymous)"
+
+var (
+	// reKeywordName は `sub name` `function Get-Item` のように、引数リストなしで
+	// キーワードの直後に関数名が続くヘッダー（Perl・PowerShell・シェル）。
+	reKeywordName = regexp.MustCompile(`\b(?:sub|function)\s+([\w:.-]+)\s*$`)
+	// reAssignedName は無名関数を代入・プロパティに設定する `name = (...) =>` `name: function` の name。
+	reAssignedName = regexp.MustCompile(`([A-Za-z_$][\w$]*)\s*(?::[^=:]*)?[=:]\s*(?:async\s+)?(?:function\b|\(|[A-Za-z_$][\w$]*\s*=>)`)
+	// reWord はヘッダー内の単語。
+	reWord = regexp.MustCompile(`[A-Za-z_]\w*`)
+)
+
+// controlWords は制御構文のキーワード。`if (x) {` のように関数名の位置にあっても関数ではない。
+var controlWords = map[string]bool{
+	"if": true, "else": true, "elif": true, "elseif": true, "for": true, "foreach": true, "while": true,
+	"do": true, "switch": true, "case": true, "when": true, "match": true, "catch": true, "try": true,
+	"finally": true, "with": true, "using": true, "lock": true, "synchronized": true, "fixed": true,
+	"return": true, "throw": true, "await": true, "yield": true, "guard": true, "unless": true, "until": true,
+	"sizeof": true, "typeof": true,
+}
+
+// declarationWords は関数名の前にあれば関数の宣言ではないと判断する単語
+// （型・名前空間の宣言、インスタンス生成）。
+var declarationWords = map[string]bool{
+	"new": true, "class": true, "struct": true, "interface": true, "enum": true, "record": true,
+	"object": true, "trait": true, "impl": true, "namespace": true, "extends": true, "implements": true,
+	"union": true, "contract": true, "library": true,
+}
+
+// functionKeywords は関数の宣言に専用のキーワードを使う言語と、そのキーワード。
+// これらの言語では `repeat(3) {` のような末尾ラムダ付きの呼び出しと区別するため、キーワードを必須とする。
+var functionKeywords = map[string][]string{
+	"Rust":     {"fn"},
+	"Zig":      {"fn"},
+	"Kotlin":   {"fun"},
+	"Scala":    {"def"},
+	"Swift":    {"func", "init", "deinit", "subscript"},
+	"PHP":      {"function"},
+	"Solidity": {"function", "constructor", "modifier", "receive", "fallback"},
+}
+
+// lambdaArrows はブロック本体を持つラムダ式の矢印。
+// Rust・Scala の match の `=>` や Kotlin の when の `->` と区別するため、言語ごとに指定する。
+var lambdaArrows = map[string]string{
+	"JavaScript": "=>",
+	"TypeScript": "=>",
+	"C#":         "=>",
+	"Java":       "->",
+}
+
+// parseBraceHeader は `{` の直前のヘッダーが関数であれば、関数名とヘッダー内の開始位置を返す。
+func parseBraceHeader(h string, syn syntax.Syntax) (string, int, bool) {
+	start := segmentStart(h)
+	seg := strings.TrimRight(h[start:], " \t")
+	if strings.TrimSpace(seg) == "" {
+		return "", 0, false
+	}
+
+	// アロー関数・ラムダ式: `(a) => {` `x => {` `(a, b) -> {`
+	if arrow := lambdaArrows[syn.Name]; arrow != "" && strings.HasSuffix(seg, arrow) &&
+		(arrow == "=>" || strings.HasSuffix(strings.TrimSpace(seg[:len(seg)-2]), ")")) {
+		if slices.Contains(reWord.FindAllString(seg, -1), "case") {
+			return "", 0, false
+		}
+		name, pos := anonymous(seg)
+		return name, start + pos, true
+	}
+
+	// 引数リストのないキーワード形式: `sub name {` `function Get-Item {`
+	if m := reKeywordName.FindStringSubmatchIndex(seg); m != nil {
+		return seg[m[2]:m[3]], start + m[2], true
+	}
+
+	open, closing := parenGroup(seg)
+	if open < 0 {
+		return "", 0, false
+	}
+	trailer := strings.TrimSpace(seg[closing+1:])
+	if strings.HasPrefix(trailer, ".") || strings.HasPrefix(trailer, "?.") || strings.HasPrefix(trailer, "(") {
+		return "", 0, false
+	}
+	if strings.Contains(trailer, "=") && !(syn.Name == "Scala" && strings.HasSuffix(trailer, "=")) {
+		return "", 0, false
+	}
+	// 戻り値の型・throws・where 句以外の文が続く場合（`foo(a) if (x) {`）は関数ではない
+	if slices.ContainsFunc(reWord.FindAllString(trailer, -1), func(w string) bool { return controlWords[w] || declarationWords[w] }) {
+		return "", 0, false
+	}
+
+	name, pos := identBefore(seg, open)
+	before := strings.TrimSpace(seg[:pos])
+	switch {
+	case name == "" && strings.HasSuffix(before, "]"):
+		// C++ のラムダ式: `[&](int x) {`
+		return anonymousName, start + strings.LastIndex(seg[:pos], "["), true
+	case name == "" && (before == "" || before == "async"):
+		// Dart の無名関数: `() {` `() async {`
+		return anonymousName, start + firstNonSpace(seg), true
+	case name == "":
+		return "", 0, false
+	case name == "function":
+		// JavaScript・PHP の無名関数: `function (a) {`
+		name, pos := anonymous(seg[:pos+len(name)])
+		return name, start + pos, true
+	}
+
+	words := reWord.FindAllString(before, -1)
+	if controlWords[name] || slices.ContainsFunc(words, func(w string) bool { return controlWords[w] || declarationWords[w] }) {
+		return "", 0, false
+	}
+	if kws, ok := functionKeywords[syn.Name]; ok && !slices.Contains(kws, name) &&
+		(len(words) == 0 || !slices.Contains(kws, words[len(words)-1])) {
+		return "", 0, false
+	}
+	return name, start + pos, true
+}
+
+// segmentStart はヘッダーのうち、閉じていない最も内側の ( [ と、その内側の最後の `,` より後の位置を返す。
+// `describe("x", function() {` のように引数として渡す無名関数のヘッダーを切り出す。
+func segmentStart(h string) int {
+	var opens []int
+	start := 0
+	for i := 0; i < len(h); i++ {
+		switch h[i] {
+		case '(', '[':
+			opens = append(opens, start)
+			start = i + 1
+		case ')', ']':
+			if n := len(opens); n > 0 {
+				start = opens[n-1]
+				opens = opens[:n-1]
+			}
+		case ',':
+			if len(opens) > 0 {
+				start = i + 1
+			}
+		}
+	}
+	return start
+}
+
+// parenGroup は s の最も外側の最初の (...)（アノテーション `@Name(...)` を除く）の開始・終了位置を返す。
+// 見つからない場合や閉じていない場合は -1 を返す。
+func parenGroup(s string) (int, int) {
+	depth, open := 0, -1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			if depth == 0 {
+				open = i
+			}
+			depth++
+		case ')':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if _, pos := identBefore(s, open); pos > 0 && s[pos-1] == '@' {
+				open = -1
+				continue
+			}
+			return open, i
+		}
+	}
+	return -1, -1
+}
+
+// identBefore は s[i] の直前の識別子（ジェネリクスの <...> を読み飛ばす）とその開始位置を返す。
+// `Foo::bar` `String.ext` `~Foo` のような修飾付きの名前も1つの名前として返す。
+func identBefore(s string, i int) (string, int) {
+	end := len(strings.TrimRight(s[:i], " \t"))
+	if end > 0 && s[end-1] == '>' {
+		depth := 0
+		for j := end - 1; j >= 0; j-- {
+			if s[j] == '>' {
+				depth++
+			} else if s[j] == '<' {
+				depth--
+			}
+			if depth == 0 {
+				end = len(strings.TrimRight(s[:j], " \t"))
+				break
+			}
+		}
+	}
+	begin := end
+	for begin > 0 && (isIdentByte(s[begin-1]) || strings.IndexByte(".:~#-", s[begin-1]) >= 0) {
+		begin--
+	}
+	name := strings.TrimLeft(s[begin:end], ".:-")
+	begin = end - len(name)
+	if name == "" || '0' <= name[0] && name[0] <= '9' {
+		return "", end
+	}
+	return name, begin
+}
+
+// anonymous は無名関数の名前と開始位置を返す。`name = () =>` のように代入先の名前があればそれを使う。
+func anonymous(seg string) (string, int) {
+	if m := reAssignedName.FindAllStringSubmatchIndex(seg, -1); m != nil {
+		last := m[len(m)-1]
+		return seg[last[2]:last[3]], last[2]
+	}
+	return anonymousName, firstNonSpace(seg)
+}
+
+// firstNonSpace は s の最初の空白以外の文字の位置を返す。
+func firstNonSpace(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " \t"))
+}
@@ -0,0 +1,140 @@
+package funcs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+)
+
+// Declaration はファイルのトップレベルにある宣言（関数・型）1つ分。
+type Declaration struct {
+	Kind string // KindFunction、または宣言のキーワード（"class" "interface" "type" 等）
+	Name string
+	Line int // 宣言の行（1 始まり）
+}
+
+// KindFunction は関数の宣言の Kind。
+const KindFunction = "function"
+
+// typeKeywords は型（またはそれに相当する単位）を宣言するキーワード。
+var typeKeywords = map[string]bool{
+	"class": true, "interface": true, "enum": true, "struct": true, "trait": true, "record": true,
+	"object": true, "union": true, "protocol": true, "contract": true, "library": true, "type": true,
+	"typealias": true, "namespace": true, "module": true, "package": true, "impl": true, "extension": true,
+}
+
+// transparentKeywords は内側の宣言をトップレベルとみなすブロックのキーワード。
+var transparentKeywords = map[string]bool{"namespace": true, "module": true, "package": true}
+
+// extensionKeywords は既存の型にメソッドを追加するブロックのキーワード（宣言としては数えない）。
+var extensionKeywords = map[string]bool{"impl": true, "extension": true}
+
+// anonymousKeywords は名前なしで宣言できる型のキーワード（`typedef struct {` `export default class {`）。
+var anonymousKeywords = map[string]bool{"class": true, "struct": true, "union": true, "enum": true, "namespace": true}
+
+// aliasKeywords はブロックを持たずに `;` で終わる型の宣言のキーワード。
+var aliasKeywords = map[string]bool{"type": true, "typealias": true}
+
+// Declarations は style の方式でソースのトップレベルの宣言を検出し、出現順に返す。
+// メソッドや入れ子の型は所属する型の一部として数えない。namespace・module の内側はトップレベルとみなす。
+// 宣言がない場合も nil ではなく空のスライスを返す。
+func Declarations(style Style, src []byte, syn syntax.Syntax) ([]Declaration, error) {
+	var decls []Declaration
+	switch style {
+	case StyleNone:
+		return nil, nil
+	case StyleGo:
+		d, err := goDeclarations(src)
+		if err != nil {
+			return nil, err
+		}
+		decls = d
+	case StyleBraces:
+		_, decls = detectBraces(lex(src, syn), syn)
+	case StyleIndent:
+		_, decls = detectIndent(lex(src, syn))
+	case StyleEnd:
+		_, decls = detectEnd(lex(src, syn))
+	default:
+		return nil, fmt.Errorf("unknown function detection style: %q", style)
+	}
+	if decls == nil {
+		decls = []Declaration{}
+	}
+	return decls, nil
+}
+
+// goDeclarations は Go のトップレベルの宣言を返す。
+// 関数（メソッドを除く）と型は1つずつ、const・var は宣言ブロック（`const (...)`）ごとに1つと数える。
+func goDeclarations(src []byte) ([]Declaration, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var decls []Declaration
+	for _, decl := range file.Decls {
+		line := fset.Position(decl.Pos()).Line
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				decls = append(decls, Declaration{Kind: KindFunction, Name: d.Name.Name, Line: line})
+			}
+		case *ast.GenDecl:
+			switch d.Tok {
+			case token.TYPE:
+				for _, spec := range d.Specs {
+					ts := spec.(*ast.TypeSpec)
+					decls = append(decls, Declaration{Kind: "type", Name: ts.Name.Name, Line: fset.Position(ts.Pos()).Line})
+				}
+			case token.CONST, token.VAR:
+				decls = append(decls, Declaration{Kind: d.Tok.String(), Name: goSpecName(d.Specs[0]), Line: line})
+			}
+		}
+	}
+	return decls, nil
+}
+
+// goSpecName は const・var の宣言ブロックの最初の名前を返す。
+func goSpecName(spec ast.Spec) string {
+	if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) > 0 {
+		return vs.Names[0].Name
+	}
+	return ""
+}
+
+// declaration はヘッダーが型の宣言（`class Name` `enum class Name`）であれば、その宣言を返す。
+// 最後の行から順に、前の行を含めたヘッダーを調べる。
+func (h *braceHeader) declaration() (Declaration, bool) {
+	for i := len(h.starts) - 1; i >= -1; i-- {
+		offset := 0
+		if i >= 0 {
+			offset = h.starts[i]
+		}
+		text := string(h.text[offset:])
+		if strings.HasPrefix(strings.TrimSpace(text), `extern ""`) {
+			// extern "C" { ... } の内側はトップレベルとみなす
+			return Declaration{Kind: "namespace", Line: h.lines[offset]}, true
+		}
+		words := reWord.FindAllStringIndex(text, -1)
+		for j := 0; j+1 < len(words); j++ {
+			kw, name := text[words[j][0]:words[j][1]], text[words[j+1][0]:words[j+1][1]]
+			if !typeKeywords[kw] || typeKeywords[name] || declarationWords[name] {
+				continue
+			}
+			return Declaration{Kind: kw, Name: name, Line: h.lines[offset+words[j][0]]}, true
+		}
+		// 名前のない namespace・構造体・クラス
+		if n := len(words); n > 0 && strings.HasSuffix(strings.TrimSpace(text), text[words[n-1][0]:words[n-1][1]]) {
+			if kw := text[words[n-1][0]:words[n-1][1]]; anonymousKeywords[kw] {
+				return Declaration{Kind: kw, Line: h.lines[offset+words[n-1][0]]}, true
+			}
+		}
+	}
+	return Declaration{}, false
+}
@@ -0,0 +1,93 @@
+package funcs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// summarizeDecls は宣言を "種別 名前" の一覧にするヘルパー。
+func summarizeDecls(decls []Declaration) []string {
+	out := make([]string, len(decls))
+	for i, d := range decls {
+		out[i] = fmt.Sprintf("%s %s", d.Kind, d.Name)
+	}
+	return out
+}
+
+func TestDeclarations_Go(t *testing.T) {
+	src := `package main
+
+type (
+	A struct{}
+	B interface{}
+)
+
+const (
+	X = iota
+	Y
+)
+
+var v = 1
+
+func (A) Method() {}
+
+func main() {}
+`
+	decls, err := Declarations(StyleGo, []byte(src), syntax.Syntax{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"type A", "type B", "const X", "var v", "function main"}, summarizeDecls(decls))
+	assert.Equal(t, 4, decls[0].Line)
+
+	_, err = Declarations(StyleGo, []byte("package main\nfunc {"), syntax.Syntax{})
+	assert.Error(t, err)
+}
+
+func TestDeclarations_Languages(t *testing.T) {
+	tests := []struct {
+		file  string
+		style Style
+		syn   syntax.Syntax
+		want  []string
+	}{
+		{"sample.ts", StyleBraces, withName(cSyntax, "TypeScript"), []string{"class Foo", "function top"}},
+		{"sample.java", StyleBraces, withName(cSyntax, "Java"), []string{"class A"}},
+		{"sample.rs", StyleBraces, withName(cSyntax, "Rust"), []string{}},
+		{"sample.cpp", StyleBraces, withName(cSyntax, "C++"), []string{"function add", "struct point", "function main"}},
+		{"sample.py", StyleIndent, syntax.Syntax{Name: "Python", LineComment: []string{"#"}, BlockStart: `"""`, BlockEnd: `"""`}, []string{
+			"class Foo", "function top",
+		}},
+		{"sample.rb", StyleEnd, syntax.Syntax{Name: "Ruby", LineComment: []string{"#"}}, []string{"class Invoice"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			require.NoError(t, err)
+			decls, err := Declarations(tt.style, src, tt.syn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, summarizeDecls(decls))
+		})
+	}
+}
+
+func TestDeclarations_Namespaces(t *testing.T) {
+	src := "namespace App {\n    public class A {\n        void M() {}\n    }\n    interface IB {}\n}\ntype Alias = string;\n"
+	decls, err := Declarations(StyleBraces, []byte(src), withName(cSyntax, "C#"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"class A", "interface IB", "type Alias"}, summarizeDecls(decls))
+
+	// 宣言がない場合も空のスライスを返す（未対応の言語と区別する）
+	decls, err = Declarations(StyleBraces, []byte("x = 1;\n"), withName(cSyntax, "C"))
+	require.NoError(t, err)
+	assert.NotNil(t, decls)
+	assert.Empty(t, decls)
+
+	decls, err = Declarations(StyleNone, []byte("class A {}"), syntax.Syntax{})
+	require.NoError(t, err)
+	assert.Nil(t, decls)
+}
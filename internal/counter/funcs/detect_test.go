@@ -0,0 +1,131 @@
+package funcs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	cSyntax      = syntax.Syntax{LineComment: []string{"//"}, BlockStart: "/*", BlockEnd: "*/"}
+	scriptSyntax = syntax.Syntax{LineComment: []string{"#"}}
+)
+
+// withName は言語名を設定した syntax.Syntax を返すヘルパー。
+func withName(syn syntax.Syntax, name string) syntax.Syntax {
+	syn.Name = name
+	return syn
+}
+
+// summarize は検出結果を "名前 開始行-終了行" の一覧にするヘルパー。
+func summarize(fns []Function) []string {
+	out := make([]string, len(fns))
+	for i, fn := range fns {
+		out[i] = fmt.Sprintf("%s %d-%d", fn.Name, fn.Line, fn.EndLine)
+	}
+	return out
+}
+
+func TestDetect_Languages(t *testing.T) {
+	tests := []struct {
+		file  string
+		style Style
+		syn   syntax.Syntax
+		want  []string
+	}{
+		{"sample.ts", StyleBraces, withName(cSyntax, "TypeScript"), []string{
+			"handler 4-8", "constructor 10-12", "render 15-18", "top 21-33",
+			"(anonymous) 22-24", "(anonymous) 25-29", "(anonymous) 26-28", "method 36-38", "prop 39-40",
+		}},
+		{"sample.java", StyleBraces, withName(cSyntax, "Java"), []string{
+			"find 11-30", "run 16-18", "(anonymous) 23-25", "A 32-35",
+		}},
+		{"sample.rs", StyleBraces, withName(cSyntax, "Rust"), []string{"new 2-4", "parse 6-18"}},
+		{"sample.kt", StyleBraces, withName(cSyntax, "Kotlin"), []string{"bar 2-11"}},
+		{"sample.cpp", StyleBraces, withName(cSyntax, "C++"), []string{"add 4-8", "main 12-21", "(anonymous) 14-16"}},
+		{"sample.sh", StyleBraces, withName(scriptSyntax, "Shell"), []string{"greet 2-4", "deploy 6-10"}},
+		{"sample.py", StyleIndent, syntax.Syntax{Name: "Python", LineComment: []string{"#"}, BlockStart: `"""`, BlockEnd: `"""`}, []string{
+			"Foo.value 9-10", "Foo.fetch 12-21", "Foo.fetch.inner 18-19", "top 24-27",
+		}},
+		{"sample.rb", StyleEnd, syntax.Syntax{Name: "Ruby", LineComment: []string{"#"}, BlockStart: "=begin", BlockEnd: "=end"}, []string{
+			"Billing::Invoice#total 3-11", "Billing::Invoice.build 13-13",
+			"Billing::Invoice#helper 15-15", "Billing::Invoice#create 18-20",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			require.NoError(t, err)
+			fns, err := Detect(tt.style, src, tt.syn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, summarize(fns))
+		})
+	}
+}
+
+func TestDetect_CodeLines(t *testing.T) {
+	src := "int f() {\n  // comment\n\n  /* block\n     comment */\n  return 1;\n}\n"
+	fns, err := Detect(StyleBraces, []byte(src), withName(cSyntax, "C"))
+	require.NoError(t, err)
+	require.Len(t, fns, 1)
+	assert.Equal(t, Function{Name: "f", Line: 1, EndLine: 7, TotalLines: 7, CodeLines: 3}, fns[0])
+}
+
+func TestDetect_LineEndings(t *testing.T) {
+	// 行数のカウントと同じく \r\n・単独の \r も改行として扱い、行番号を揃える
+	src := "int f() {\n  // comment\n\n  /* block\n     comment */\n  return 1;\n}\n"
+	for name, eol := range map[string]string{"CRLF": "\r\n", "CR": "\r"} {
+		t.Run(name, func(t *testing.T) {
+			fns, err := Detect(StyleBraces, []byte(strings.ReplaceAll(src, "\n", eol)), withName(cSyntax, "C"))
+			require.NoError(t, err)
+			require.Len(t, fns, 1)
+			assert.Equal(t, Function{Name: "f", Line: 1, EndLine: 7, TotalLines: 7, CodeLines: 3}, fns[0])
+		})
+	}
+}
+
+func TestDetect_IgnoresBracesInStringsAndComments(t *testing.T) {
+	src := "function f() {\n  const s = \"}\";\n  // }\n  const t = `\n}`;\n}\n"
+	fns, err := Detect(StyleBraces, []byte(src), withName(cSyntax, "JavaScript"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"f 1-6"}, summarize(fns))
+}
+
+func TestDetect_NotFunctions(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		src  string
+	}{
+		{"control", "C", "if (x) {\n}\nwhile (y) {\n}\nfor (i = 0; i < n; i++) {\n}\n"},
+		{"else if", "C", "if (x) {\n} else if (y) {\n}\n"},
+		{"class", "Kotlin", "class Foo(val x: Int) {\n}\n"},
+		{"trailing lambda", "Kotlin", "repeat(3) {\n}\n"},
+		{"match arm", "Rust", "match x {\n    A => {}\n}\n"},
+		{"call then block", "JavaScript", "doSomething(a)\nif (x) {\n}\n"},
+		{"object literal", "JavaScript", "foo({\n  a: 1,\n})\n"},
+		{"anonymous class", "Java", "Runnable r = new Runnable() {\n};\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fns, err := Detect(StyleBraces, []byte(tt.src), withName(cSyntax, tt.lang))
+			require.NoError(t, err)
+			assert.Empty(t, fns)
+		})
+	}
+}
+
+func TestDetect_UnknownStyle(t *testing.T) {
+	_, err := Detect(Style("unknown"), []byte("x"), syntax.Syntax{})
+	assert.Error(t, err)
+
+	fns, err := Detect(StyleNone, []byte("func f() {}"), syntax.Syntax{})
+	assert.NoError(t, err)
+	assert.Empty(t, fns)
+}
@@ -0,0 +1,142 @@
+package funcs
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// reEndDef は Ruby・Crystal のメソッド定義（`def name` `def self.name`）。
+	reEndDef = regexp.MustCompile(`^def\s+((?:self\.)?[\w.]+[?!=]?|[^\s(]+)`)
+	// reEndlessDef は end を持たない1行のメソッド定義（`def name(x) = expr`）。
+	reEndlessDef = regexp.MustCompile(`^def\s+[\w.]+[?!]?\s*(?:\([^)]*\))?\s*=[^=~>]`)
+	// reEndWord は文中の単語。メソッド呼び出し（`.end`）やシンボル（`:do`）は除く。
+	reEndWord = regexp.MustCompile(`(^|[^.:\w])([A-Za-z_]\w*[?!]?)`)
+)
+
+// endOpeners は文の先頭にある場合にブロックを開始するキーワード。
+// 文の途中の if / unless / while / until は後置修飾子のためブロックを開始しない。
+var endOpeners = map[string]bool{
+	"def": true, "class": true, "module": true, "if": true, "unless": true, "while": true, "until": true,
+	"case": true, "begin": true, "for": true, "struct": true, "enum": true, "lib": true, "macro": true,
+	"annotation": true, "union": true,
+}
+
+// endDeclKeywords は def 以外で宣言として数えるブロックのキーワード（module はトップレベルとみなす）。
+var endDeclKeywords = map[string]bool{"class": true, "struct": true, "enum": true, "lib": true}
+
+// endScope は end で閉じるブロック1つ分。
+type endScope struct {
+	fn    bool
+	kind  string // ブロックを開始したキーワード
+	name  string // クラス・モジュール名（関数の場合は修飾済みの関数名）
+	start int
+}
+
+// detectEnd は def ... end のブロックから関数を検出する（Ruby・Crystal）。
+// ブロックを開始するキーワードと end の対応を数え、def に対応する end までを関数とみなす。
+func detectEnd(lines []line) ([]Function, []Declaration) {
+	var (
+		fns   []Function
+		decls []Declaration
+		stack []endScope
+	)
+	// module の内側はトップレベルとみなす
+	declare := func(kind, name string, ln int) {
+		if !slices.ContainsFunc(stack, func(s endScope) bool { return s.kind != "module" }) {
+			decls = append(decls, Declaration{Kind: kind, Name: name, Line: ln})
+		}
+	}
+	for i, l := range lines {
+		ln := i + 1
+		if l.inString {
+			continue
+		}
+		for _, stmt := range strings.Split(l.text, ";") {
+			stmt = strings.TrimSpace(stmt)
+			for _, modifier := range []string{"private ", "protected ", "public "} {
+				stmt = strings.TrimPrefix(stmt, modifier)
+			}
+			if stmt == "" {
+				continue
+			}
+			if reEndlessDef.MatchString(stmt) {
+				m := reEndDef.FindStringSubmatch(stmt)
+				declare(KindFunction, m[1], ln)
+				fns = append(fns, newFunction(endQualify(stack, m[1]), ln, ln, lines))
+				continue
+			}
+			opened := false
+			for j, m := range reEndWord.FindAllStringSubmatch(stmt, -1) {
+				switch word := m[2]; {
+				case word == "end":
+					if n := len(stack); n > 0 {
+						if scope := stack[n-1]; scope.fn {
+							fns = append(fns, newFunction(scope.name, scope.start, ln, lines))
+						}
+						stack = stack[:n-1]
+					}
+				case j == 0 && endOpeners[word] || afterAssign(stmt, word) && (word == "if" || word == "case" || word == "begin"):
+					scope := endOpen(stack, word, stmt, ln)
+					switch {
+					case word == "def":
+						if m := reEndDef.FindStringSubmatch(stmt); m != nil {
+							declare(KindFunction, m[1], ln)
+						}
+					case endDeclKeywords[word] && scope.name != "":
+						declare(word, scope.name, ln)
+					}
+					stack = append(stack, scope)
+					opened = true
+				case word == "do" && !opened:
+					// `while x do` の do は while と同じブロックのため数えない
+					stack = append(stack, endScope{start: ln})
+					opened = true
+				}
+			}
+		}
+	}
+	sortByLine(fns)
+	return fns, decls
+}
+
+// endOpen は word で始まるブロックを返す。def は関数、class / module は名前空間として名前を記録する。
+func endOpen(stack []endScope, word, stmt string, ln int) endScope {
+	scope := endScope{kind: word, start: ln}
+	switch word {
+	case "def":
+		if m := reEndDef.FindStringSubmatch(stmt); m != nil {
+			scope.fn, scope.name = true, endQualify(stack, m[1])
+		}
+	case "class", "module", "struct", "enum", "lib":
+		if fields := strings.Fields(stmt); len(fields) > 1 && fields[1] != "<<" {
+			scope.name = fields[1]
+		}
+	}
+	return scope
+}
+
+// endQualify はメソッド名を外側のクラス・モジュール名で修飾する
+// （インスタンスメソッドは "Class#name"、`def self.name` は "Class.name"）。
+func endQualify(stack []endScope, name string) string {
+	var outer []string
+	for _, scope := range stack {
+		if !scope.fn && scope.name != "" {
+			outer = append(outer, scope.name)
+		}
+	}
+	if len(outer) == 0 {
+		return name
+	}
+	if rest, ok := strings.CutPrefix(name, "self."); ok {
+		return strings.Join(outer, "::") + "." + rest
+	}
+	return strings.Join(outer, "::") + "#" + name
+}
+
+// afterAssign は stmt 内で word が代入の右辺の先頭（`x = if ...`）にあるかを返す。
+func afterAssign(stmt, word string) bool {
+	i := strings.Index(stmt, "= "+word+" ")
+	return i > 0 && !strings.ContainsAny(stmt[i-1:i], "=!<>")
+}
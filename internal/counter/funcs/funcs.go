@@ -0,0 +1,83 @@
+// Package funcs はソースコードから関数（メソッド・無名関数を含む）の範囲を検出し、
+// 関数ごとの行数を計測する。あわせてファイルのトップレベルの宣言（関数・型）も検出する。
+//
+// Go は go/parser で正確に解析し、それ以外の言語はコメント・文字列を取り除いた
+// 字句解析の結果から、波括弧の対応やインデント、def ... end のブロックで関数の範囲を推定する。
+package funcs
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+)
+
+// Function は検出した関数1つ分の位置と行数。
+type Function struct {
+	Name       string // 関数名（メソッドは "(*T).Name"、無名関数は "Outer.func1"）
+	Line       int    // 開始行（1 始まり）
+	EndLine    int    // 終了行（1 始まり）
+	TotalLines int    // 開始行から終了行までの全行数
+	CodeLines  int    // コード行数（コメント・空行除外）
+}
+
+// Style は関数の検出方式。
+type Style string
+
+const (
+	StyleNone   Style = ""       // 関数を検出しない
+	StyleGo     Style = "go"     // go/parser による解析
+	StyleBraces Style = "braces" // 波括弧の対応（C 系言語・シェル等）
+	StyleIndent Style = "indent" // def とインデントのブロック（Python）
+	StyleEnd    Style = "end"    // def ... end のブロック（Ruby・Crystal）
+)
+
+// Detect は style の方式でソースから関数を検出し、開始行の順に返す。
+// StyleGo で構文エラーのあるソースはエラーを返す。それ以外の方式は推定のため、
+// 構文が崩れていてもエラーにはせず、範囲を特定できた関数のみを返す。
+func Detect(style Style, src []byte, syn syntax.Syntax) ([]Function, error) {
+	switch style {
+	case StyleNone:
+		return nil, nil
+	case StyleGo:
+		return ParseGo(src)
+	case StyleBraces:
+		fns, _ := detectBraces(lex(src, syn), syn)
+		return fns, nil
+	case StyleIndent:
+		fns, _ := detectIndent(lex(src, syn))
+		return fns, nil
+	case StyleEnd:
+		fns, _ := detectEnd(lex(src, syn))
+		return fns, nil
+	}
+	return nil, fmt.Errorf("unknown function detection style: %q", style)
+}
+
+// countCode は start〜end 行（1 始まり、両端を含む）のうち code が true の行数を返す。
+// code は行番号をインデックスとするため、code[0] は使用しない。
+func countCode(code []bool, start, end int) int {
+	n := 0
+	for line := start; line <= end && line < len(code); line++ {
+		if code[line] {
+			n++
+		}
+	}
+	return n
+}
+
+// newFunction は start〜end 行（1 始まり）の関数を、lines のコード行判定で計測して返す。
+func newFunction(name string, start, end int, lines []line) Function {
+	code := 0
+	for i := start; i <= end && i <= len(lines); i++ {
+		if lines[i-1].code {
+			code++
+		}
+	}
+	return Function{Name: name, Line: start, EndLine: end, TotalLines: end - start + 1, CodeLines: code}
+}
+
+// sortByLine は関数を開始行の順に並べる。ブロックの終わりで検出した入れ子の関数が外側より先に並ぶのを直す。
+func sortByLine(fns []Function) {
+	slices.SortStableFunc(fns, func(a, b Function) int { return a.Line - b.Line })
+}
@@ -0,0 +1,115 @@
+package funcs
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
+// ParseGo は Go のソースを go/parser で解析し、関数宣言（FuncDecl）と
+// 関数リテラル（FuncLit）をソース上の出現順に返す。
+// 構文エラーのあるソースはエラーを返す。
+func ParseGo(src []byte) ([]Function, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	code := goCodeLines(fset, src)
+
+	var fns []Function
+	add := func(name string, node ast.Node) {
+		start := fset.Position(node.Pos()).Line
+		end := fset.Position(node.End()).Line
+		fns = append(fns, Function{
+			Name:       name,
+			Line:       start,
+			EndLine:    end,
+			TotalLines: end - start + 1,
+			CodeLines:  countCode(code, start, end),
+		})
+	}
+
+	for _, decl := range file.Decls {
+		name := "glob"
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			name = goFuncName(fd)
+			add(name, fd)
+		}
+		walkFuncLits(decl, name, false, add)
+	}
+	return fns, nil
+}
+
+// walkFuncLits は node の内側にある関数リテラルを outer.func1, outer.func2 … と名付けて add に渡す。
+// 入れ子の関数リテラルは outer.func1.1 のように親の名前に連番を付ける（Go ランタイムの命名に準じる）。
+func walkFuncLits(node ast.Node, outer string, nested bool, add func(string, ast.Node)) {
+	n := 0
+	ast.Inspect(node, func(child ast.Node) bool {
+		lit, ok := child.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		n++
+		name := fmt.Sprintf("%s.func%d", outer, n)
+		if nested {
+			name = fmt.Sprintf("%s.%d", outer, n)
+		}
+		add(name, lit)
+		walkFuncLits(lit.Body, name, true, add)
+		return false
+	})
+}
+
+// goFuncName は関数宣言の名前を返す。メソッドはレシーバー型を付けて "(*T).Name" とする。
+func goFuncName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+	return fmt.Sprintf("(%s).%s", goRecvType(fd.Recv.List[0].Type), fd.Name.Name)
+}
+
+// goRecvType はレシーバーの型名を返す。型パラメータは省略する。
+func goRecvType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + goRecvType(t.X)
+	case *ast.IndexExpr:
+		return goRecvType(t.X)
+	case *ast.IndexListExpr:
+		return goRecvType(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return "?"
+}
+
+// goCodeLines はコメント以外のトークンを含む行を true とした、行番号をインデックスとするスライスを返す。
+// 複数行にわたる raw 文字列リテラルは、またがるすべての行をコード行とする。
+func goCodeLines(fset *token.FileSet, src []byte) []bool {
+	file := fset.AddFile("", -1, len(src))
+	code := make([]bool, bytes.Count(src, []byte("\n"))+2)
+
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// 改行で自動挿入されたセミコロンはコードとして数えない
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		start := file.Line(pos)
+		end := start + strings.Count(lit, "\n")
+		for line := start; line <= end && line < len(code); line++ {
+			code[line] = true
+		}
+	}
+	return code
+}
@@ -0,0 +1,43 @@
+package funcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goSource = `package main
+
+// Handle はリクエストを処理する。
+func (s *Server[T]) Handle() {
+	// コメント行
+
+	run(func() {
+		go func() {}()
+	})
+	x := ` + "`a\nb`" + `
+	_ = x
+}
+
+var hook = func() {}
+
+func main() {}
+`
+
+func TestParseGo(t *testing.T) {
+	fns, err := ParseGo([]byte(goSource))
+	require.NoError(t, err)
+
+	require.Len(t, fns, 5)
+	assert.Equal(t, Function{Name: "(*Server).Handle", Line: 4, EndLine: 13, TotalLines: 10, CodeLines: 8}, fns[0])
+	assert.Equal(t, Function{Name: "(*Server).Handle.func1", Line: 7, EndLine: 9, TotalLines: 3, CodeLines: 3}, fns[1])
+	assert.Equal(t, "(*Server).Handle.func1.1", fns[2].Name)
+	assert.Equal(t, Function{Name: "glob.func1", Line: 15, EndLine: 15, TotalLines: 1, CodeLines: 1}, fns[3])
+	assert.Equal(t, "main", fns[4].Name)
+}
+
+func TestParseGo_SyntaxError(t *testing.T) {
+	_, err := ParseGo([]byte("package main\nfunc {"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package funcs
+
+import (
+	"regexp"
+	"strings"
+)
+
+// reIndentBlock は Python の関数・クラスの定義行。
+var reIndentBlock = regexp.MustCompile(`^(?:async\s+)?(def|class)\s+([A-Za-z_]\w*)`)
+
+// indentScope はインデントで表されるブロック（関数・クラス）1つ分。
+type indentScope struct {
+	fn     bool
+	name   string // クラス名で修飾した名前（"Class.method"）
+	indent int
+	start  int // 開始行（1 始まり）
+}
+
+// detectIndent は def とインデントのブロックから関数を検出する（Python）。
+// 関数は def の行から、def より深くインデントされた最後のコード行までとする。
+// 括弧の内側や行末の \ で継続する行、複数行の文字列の内側の行はインデントの判定に使わない。
+func detectIndent(lines []line) ([]Function, []Declaration) {
+	var (
+		fns      []Function
+		decls    []Declaration
+		stack    []indentScope
+		lastCode int  // 直前のコード行（1 始まり）
+		depth    int  // 行をまたぐ () [] {} の深さ
+		cont     bool // 前の行が \ で終わっている
+	)
+	closeScopes := func(indent int) {
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			scope := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if scope.fn {
+				fns = append(fns, newFunction(scope.name, scope.start, lastCode, lines))
+			}
+		}
+	}
+
+	for i, l := range lines {
+		ln := i + 1
+		text := strings.TrimSpace(l.text)
+		structural := !l.inString && depth == 0 && !cont && text != ""
+		if structural {
+			closeScopes(l.indent)
+			if m := reIndentBlock.FindStringSubmatch(text); m != nil {
+				if l.indent == 0 {
+					decls = append(decls, Declaration{Kind: pythonKind(m[1]), Name: m[2], Line: ln})
+				}
+				name := m[2]
+				if n := len(stack); n > 0 {
+					name = stack[n-1].name + "." + name
+				}
+				stack = append(stack, indentScope{fn: m[1] == "def", name: name, indent: l.indent, start: ln})
+			}
+		}
+		if l.code {
+			lastCode = ln
+		}
+		depth = max(depth+bracketDelta(l.text), 0)
+		cont = strings.HasSuffix(text, `\`)
+	}
+	closeScopes(0)
+	sortByLine(fns)
+	return fns, decls
+}
+
+// pythonKind は def / class を Declaration.Kind に変換する。
+func pythonKind(keyword string) string {
+	if keyword == "def" {
+		return KindFunction
+	}
+	return keyword
+}
+
+// bracketDelta は s 内の開き括弧と閉じ括弧の数の差を返す。
+func bracketDelta(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{':
+			n++
+		case ')', ']', '}':
+			n--
+		}
+	}
+	return n
+}
@@ -0,0 +1,173 @@
+package funcs
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+	"github.com/ousiassllc/linterly/internal/lines"
+)
+
+// line は1行分の字句解析の結果。
+type line struct {
+	// text はコメントを取り除き、文字列リテラルを空の "" に置き換えたテキスト。
+	// 関数の範囲の推定で、文字列やコメント内の括弧・キーワードを無視するために使う
+	text string
+	// indent は行頭の空白の幅（タブは 1 つとして数える）
+	indent int
+	// inString は行頭が複数行にわたる文字列リテラルの内側であることを表す
+	inString bool
+	// code はコード行（コメント・空行以外）であることを表す
+	code bool
+}
+
+// multiLineQuotes は複数行にわたることのある文字列リテラルの区切り記号（長いものから順に判定する）。
+var multiLineQuotes = []string{`"""`, `'''`, "`"}
+
+// lexer は行をまたいでブロックコメント・文字列リテラルの状態を保持する。
+type lexer struct {
+	syn     syntax.Syntax
+	inBlock bool   // ブロックコメントの内側
+	quote   string // 複数行の文字列リテラルの内側の場合、その区切り記号
+}
+
+// lex はソースを行ごとに字句解析する。
+// 行の分割は行数のカウントと同じく lines.Reader で行う（\n・\r\n・単独の \r を改行とする）。
+func lex(src []byte, syn syntax.Syntax) []line {
+	lx := &lexer{syn: syn}
+	var out []line
+	lr := lines.NewReader(bytes.NewReader(src))
+	for lr.Scan() {
+		out = append(out, lx.line(lr.Text()))
+	}
+	return out
+}
+
+// line は1行を字句解析する。
+func (lx *lexer) line(s string) line {
+	l := line{
+		indent:   len(s) - len(strings.TrimLeft(s, " \t")),
+		inString: lx.quote != "",
+	}
+	var b strings.Builder
+	hasString := lx.quote != "" && strings.TrimSpace(s) != ""
+
+	for i := 0; i < len(s); {
+		switch {
+		case lx.inBlock:
+			end := strings.Index(s[i:], lx.syn.BlockEnd)
+			if end < 0 {
+				i = len(s)
+				continue
+			}
+			lx.inBlock = false
+			i += end + len(lx.syn.BlockEnd)
+		case lx.quote != "":
+			end := closingQuote(s[i:], lx.quote)
+			if end < 0 {
+				i = len(s)
+				continue
+			}
+			b.WriteString(`"`)
+			lx.quote = ""
+			i += end
+		case lx.syn.BlockStart != "" && strings.HasPrefix(s[i:], lx.syn.BlockStart):
+			lx.inBlock = true
+			i += len(lx.syn.BlockStart)
+		case lx.isLineComment(s[i:]):
+			i = len(s)
+		default:
+			if q := lx.multiLineQuote(s[i:]); q != "" {
+				b.WriteString(`"`)
+				hasString = true
+				lx.quote = q
+				i += len(q)
+				continue
+			}
+			if n := lx.singleLineString(s, i); n > 0 {
+				b.WriteString(`""`)
+				hasString = true
+				i += n
+				continue
+			}
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+
+	l.text = b.String()
+	l.code = strings.TrimSpace(l.text) != "" || hasString
+	return l
+}
+
+// isLineComment は s が行コメントで始まるかを返す。
+func (lx *lexer) isLineComment(s string) bool {
+	for _, prefix := range lx.syn.LineComment {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// multiLineQuote は s が複数行の文字列リテラルで始まる場合、その区切り記号を返す。
+// PowerShell のバッククォートはエスケープ・行継続の記号のため文字列とみなさない。
+func (lx *lexer) multiLineQuote(s string) string {
+	for _, q := range multiLineQuotes {
+		if q == "`" && lx.syn.Name == "PowerShell" {
+			continue
+		}
+		if strings.HasPrefix(s, q) {
+			return q
+		}
+	}
+	return ""
+}
+
+// closingQuote は s 内で区切り記号 q が閉じる位置の直後のインデックスを返す。
+// バックスラッシュでエスケープされた文字は読み飛ばす。閉じていない場合は -1 を返す。
+func closingQuote(s, q string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], q) {
+			return i + len(q)
+		}
+	}
+	return -1
+}
+
+// singleLineString は s[i] から始まる1行内の文字列リテラル（"..." / '...'）の長さを返す。
+// 文字列リテラルでない場合や、同じ行で閉じていない場合は 0 を返す。
+// Rust のライフタイム（'a）は文字リテラルと区別して文字列とみなさない。
+func (lx *lexer) singleLineString(s string, i int) int {
+	c := s[i]
+	if c != '"' && c != '\'' {
+		return 0
+	}
+	if c == '\'' && lx.syn.Name == "Rust" && isLifetime(s[i+1:]) {
+		return 0
+	}
+	end := closingQuote(s[i+1:], string(c))
+	if end < 0 {
+		return 0
+	}
+	return 1 + end
+}
+
+// isLifetime は ' に続く s がライフタイム・ラベル（'a, 'static）であるかを返す。
+// 文字リテラルは 'a' のように 1 文字の直後で閉じる。
+func isLifetime(s string) bool {
+	n := 0
+	for n < len(s) && isIdentByte(s[n]) {
+		n++
+	}
+	return n > 0 && (n == len(s) || s[n] != '\'')
+}
+
+// isIdentByte は c が識別子に使える ASCII 文字であるかを返す。
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
@@ -0,0 +1,21 @@
+#include <stdio.h>
+
+static int
+add(int a, int b)
+{
+    /* { */
+    return a + b;
+}
+
+struct point { int x; };
+
+int main(int argc, char **argv) {
+    int arr[] = {1, 2};
+    auto f = [&](int x) {
+        return x;
+    };
+    if (argc > 1) {
+        printf("}\n");
+    }
+    return 0;
+}
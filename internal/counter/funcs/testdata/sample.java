@@ -0,0 +1,36 @@
+package x;
+
+@Service
+public class A implements B {
+    static {
+        init();
+    }
+
+    @Override
+    @GetMapping("/x")
+    public <T> List<T> find(
+            String a,
+            int b) throws IOException {
+        Runnable r = new Runnable() {
+            @Override
+            public void run() {
+                char c = '{';
+            }
+        };
+        list.forEach(x -> {
+            print(x);
+        });
+        list.forEach((x) -> {
+            print(x);
+        });
+        try {
+        } catch (Exception e) {
+        }
+        return null;
+    }
+
+    public A(int x)
+    {
+        super(x);
+    }
+}
@@ -0,0 +1,14 @@
+class Foo(val x: Int) {
+    fun bar(y: Int): Int {
+        repeat(3) {
+            println(it)
+        }
+        val z = when (y) {
+            1 -> { 2 }
+            else -> 3
+        }
+        return z
+    }
+
+    fun String.ext() = this.length
+}
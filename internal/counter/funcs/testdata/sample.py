@@ -0,0 +1,27 @@
+import os
+
+
+class Foo:
+    """Docstring
+    with def inside"""
+
+    @property
+    def value(self):
+        return 1
+
+    async def fetch(self,
+            url):
+        data = """
+not code
+"""
+        # comment
+        def inner():
+            pass
+
+        return data
+
+
+def top():
+    x = [
+1, 2]
+    return x
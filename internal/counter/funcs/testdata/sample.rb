@@ -0,0 +1,23 @@
+module Billing
+  class Invoice < Base
+    def total
+      items.each do |i|
+        sum += i if i > 0
+      end
+      while x do
+        y
+      end
+      sum
+    end
+
+    def self.build(x) = new(x)
+
+    private def helper; 1; end
+
+    class << self
+      def create
+        x = if a then b else c end
+      end
+    end
+  end
+end
@@ -0,0 +1,19 @@
+impl<'a> Parser<'a> {
+    pub fn new(src: &'a str) -> Self {
+        Self { src }
+    }
+
+    fn parse<T: Clone>(&self, x: T) -> Result<Vec<T>, Error>
+    where
+        T: Debug,
+    {
+        if let Some(x) = foo() {
+            let c = '}';
+        }
+        match x {
+            A => {}
+        }
+        let f = |x| { x + 1 };
+        Ok(vec![])
+    }
+}
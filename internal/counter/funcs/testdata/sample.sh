@@ -0,0 +1,10 @@
+#!/bin/bash
+greet() {
+  echo "hi ${1}"
+}
+
+function deploy {
+  if [ -n "$x" ]; then
+    echo '{'
+  fi
+}
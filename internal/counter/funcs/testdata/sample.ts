@@ -0,0 +1,41 @@
+import { x } from "y";
+
+export class Foo extends Bar {
+  private handler = async (req: Request): Promise<void> => {
+    if (req.ok) {
+      console.log("{");
+    }
+  };
+
+  constructor(a: number) {
+    super(a);
+  }
+
+  @Decorate()
+  render(): string {
+    return `multi
+    line { template`;
+  }
+}
+
+export function top<T>(x: T): T {
+  const arr = [1, 2].map((v) => {
+    return v;
+  });
+  describe("x", function () {
+    it('works', async () => {
+      expect(1).toBe(1);
+    });
+  });
+  for (let i = 0; i < 3; i++) {
+  }
+  return x;
+}
+
+const obj = {
+  method(a) {
+    return a;
+  },
+  prop: function () {
+  },
+};
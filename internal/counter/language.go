@@ -1,6 +1,15 @@
 package counter
 
-import "path/filepath"
+import (
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/counter/funcs"
+	"github.com/ousiassllc/linterly/internal/counter/syntax"
+	"github.com/ousiassllc/linterly/internal/lines"
+)
 
 // Language はプログラミング言語のコメント構文を定義する。
 type Language struct {
@@ -9,116 +18,49 @@ type Language struct {
 	LineCommentStart  []string // 例: ["//", "#"]
 	BlockCommentStart string   // 例: "/*"
 	BlockCommentEnd   string   // 例: "*/"
+	// SectionCounter はファイルをセクションに分けてカウントする関数。
+	// Vue/Svelte/Astro の <script>/<style> ブロックや Markdown のコードブロックのように、
+	// 1ファイル内で言語が切り替わる形式で設定する。nil の場合は行単位でコメントを判定する。
+	SectionCounter sectionCountFunc
+	// Functions は関数・トップレベルの宣言の検出方式。
+	// funcs.StyleNone（未指定）の場合は関数単位の行数・宣言数のチェックを行わない。
+	Functions funcs.Style
 }
 
-var languages = []Language{
-	{
-		Name:              "Go",
-		Extensions:        []string{".go"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "Rust",
-		Extensions:        []string{".rs"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "JavaScript",
-		Extensions:        []string{".js", ".jsx", ".mjs"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "TypeScript",
-		Extensions:        []string{".ts", ".tsx", ".mts"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "Python",
-		Extensions:        []string{".py"},
-		LineCommentStart:  []string{"#"},
-		BlockCommentStart: `"""`,
-		BlockCommentEnd:   `"""`,
-	},
-	{
-		Name:              "Ruby",
-		Extensions:        []string{".rb"},
-		LineCommentStart:  []string{"#"},
-		BlockCommentStart: "=begin",
-		BlockCommentEnd:   "=end",
-	},
-	{
-		Name:              "Java",
-		Extensions:        []string{".java"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "Kotlin",
-		Extensions:        []string{".kt", ".kts"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "C",
-		Extensions:        []string{".c", ".h"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "C++",
-		Extensions:        []string{".cpp", ".cc", ".hpp", ".hh"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "HTML",
-		Extensions:        []string{".html", ".htm", ".xml", ".svg"},
-		LineCommentStart:  nil,
-		BlockCommentStart: "<!--",
-		BlockCommentEnd:   "-->",
-	},
-	{
-		Name:              "CSS",
-		Extensions:        []string{".css"},
-		LineCommentStart:  nil,
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:              "SCSS",
-		Extensions:        []string{".scss", ".sass"},
-		LineCommentStart:  []string{"//"},
-		BlockCommentStart: "/*",
-		BlockCommentEnd:   "*/",
-	},
-	{
-		Name:             "Shell",
-		Extensions:       []string{".sh", ".bash", ".zsh"},
-		LineCommentStart: []string{"#"},
-	},
+// sectionCountFunc はファイル全体の行数・コード行数とセクションごとの内訳を返す。
+// widths が nil でない場合は、カウントする各行の表示幅も計測する。
+type sectionCountFunc func(r io.Reader, lang *Language, widths *lines.WidthChecker) (*LineCount, error)
+
+// syntax は言語のコメント構文を返す。lang が nil の場合は nil を返す。
+func (lang *Language) syntax() *syntax.Syntax {
+	if lang == nil {
+		return nil
+	}
+	return &syntax.Syntax{
+		Name:        lang.Name,
+		LineComment: lang.LineCommentStart,
+		BlockStart:  lang.BlockCommentStart,
+		BlockEnd:    lang.BlockCommentEnd,
+	}
 }
 
+// languages は対応言語の一覧。コメント構文の系統ごとに別ファイルで定義する。
+var languages = slices.Concat(cFamilyLanguages, scriptLanguages, markupLanguages)
+
 // extToLanguage は拡張子から言語へのマッピング。
 var extToLanguage map[string]*Language
 
+// nameToLanguage は小文字の言語名から言語へのマッピング。
+var nameToLanguage map[string]*Language
+
 func init() {
 	extToLanguage = make(map[string]*Language)
+	nameToLanguage = make(map[string]*Language)
 	for i := range languages {
 		for _, ext := range languages[i].Extensions {
 			extToLanguage[ext] = &languages[i]
 		}
+		nameToLanguage[strings.ToLower(languages[i].Name)] = &languages[i]
 	}
 }
 
@@ -128,3 +70,39 @@ func DetectLanguage(path string) *Language {
 	ext := filepath.Ext(path)
 	return extToLanguage[ext]
 }
+
+// LookupLanguage は Markdown のコードブロックの言語指定や Notebook のカーネル言語のような
+// 言語名から言語を検出する。拡張子（"py"）と言語名（"python"）のどちらにも対応し、
+// 大文字小文字は区別しない。対応する言語が見つからない場合は nil を返す。
+func LookupLanguage(name string) *Language {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "."))
+	if name == "" {
+		return nil
+	}
+	if lang := extToLanguage["."+name]; lang != nil {
+		return lang
+	}
+	if alias, ok := languageAliases[name]; ok {
+		name = alias
+	}
+	return nameToLanguage[name]
+}
+
+// languageAliases は拡張子・言語名のどちらとも一致しない慣用的な言語指定の別名。
+var languageAliases = map[string]string{
+	"golang":     "go",
+	"console":    "shell",
+	"csharp":     "c#",
+	"fsharp":     "f#",
+	"objc":       "objective-c",
+	"objectivec": "objective-c",
+	"postgresql": "sql",
+	"mysql":      "sql",
+	"terraform":  "hcl",
+	"ps":         "powershell",
+	"pwsh":       "powershell",
+	"elisp":      "lisp",
+	"scheme":     "lisp",
+	"racket":     "lisp",
+	"protobuf":   "protocol buffers",
+}
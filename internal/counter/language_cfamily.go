@@ -0,0 +1,149 @@
+package counter
+
+import "github.com/ousiassllc/linterly/internal/counter/funcs"
+
+// cFamilyLanguages は `//` と `/* */` を基本とする C 系構文の言語一覧。
+var cFamilyLanguages = []Language{
+	{
+		Name:              "Go",
+		Extensions:        []string{".go"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleGo,
+	},
+	{
+		Name:              "Rust",
+		Extensions:        []string{".rs"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "JavaScript",
+		Extensions:        []string{".js", ".jsx", ".mjs", ".cjs"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "TypeScript",
+		Extensions:        []string{".ts", ".tsx", ".mts", ".cts"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Java",
+		Extensions:        []string{".java"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Kotlin",
+		Extensions:        []string{".kt", ".kts"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Scala",
+		Extensions:        []string{".scala"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Groovy",
+		Extensions:        []string{".groovy", ".gradle"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "C",
+		Extensions:        []string{".c", ".h"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "C++",
+		Extensions:        []string{".cpp", ".cc", ".cxx", ".hpp", ".hh", ".hxx"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "C#",
+		Extensions:        []string{".cs", ".csx"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Objective-C",
+		Extensions:        []string{".m", ".mm"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Swift",
+		Extensions:        []string{".swift"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Dart",
+		Extensions:        []string{".dart"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		// PHP 8 の属性 `#[...]` と区別できないため `#` は行コメントとして扱わない
+		Name:              "PHP",
+		Extensions:        []string{".php"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Solidity",
+		Extensions:        []string{".sol"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Protocol Buffers",
+		Extensions:        []string{".proto"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+	},
+	{
+		Name:             "Zig",
+		Extensions:       []string{".zig"},
+		LineCommentStart: []string{"//"},
+		Functions:        funcs.StyleBraces,
+	},
+}
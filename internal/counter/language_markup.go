@@ -0,0 +1,93 @@
+package counter
+
+// markupLanguages はマークアップ・スタイルシート・設定ファイル形式の言語一覧。
+var markupLanguages = []Language{
+	{
+		Name:              "HTML",
+		Extensions:        []string{".html", ".htm", ".xml", ".svg"},
+		LineCommentStart:  nil,
+		BlockCommentStart: "<!--",
+		BlockCommentEnd:   "-->",
+	},
+	{
+		Name:              "Vue",
+		Extensions:        []string{".vue"},
+		BlockCommentStart: "<!--",
+		BlockCommentEnd:   "-->",
+		SectionCounter:    countComponent,
+	},
+	{
+		Name:              "Svelte",
+		Extensions:        []string{".svelte"},
+		BlockCommentStart: "<!--",
+		BlockCommentEnd:   "-->",
+		SectionCounter:    countComponent,
+	},
+	{
+		Name:              "Astro",
+		Extensions:        []string{".astro"},
+		BlockCommentStart: "<!--",
+		BlockCommentEnd:   "-->",
+		SectionCounter:    countComponent,
+	},
+	{
+		Name:              "Markdown",
+		Extensions:        []string{".md", ".markdown"},
+		BlockCommentStart: "<!--",
+		BlockCommentEnd:   "-->",
+		SectionCounter:    countMarkdown,
+	},
+	{
+		Name:           "Jupyter Notebook",
+		Extensions:     []string{".ipynb"},
+		SectionCounter: countNotebook,
+	},
+	{
+		Name:              "CSS",
+		Extensions:        []string{".css"},
+		LineCommentStart:  nil,
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+	},
+	{
+		Name:              "SCSS",
+		Extensions:        []string{".scss", ".sass"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+	},
+	{
+		Name:              "Less",
+		Extensions:        []string{".less"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+	},
+	{
+		Name:             "YAML",
+		Extensions:       []string{".yml", ".yaml"},
+		LineCommentStart: []string{"#"},
+	},
+	{
+		Name:             "TOML",
+		Extensions:       []string{".toml"},
+		LineCommentStart: []string{"#"},
+	},
+	{
+		Name:             "INI",
+		Extensions:       []string{".ini"},
+		LineCommentStart: []string{";", "#"},
+	},
+	{
+		Name:              "HCL",
+		Extensions:        []string{".tf", ".tfvars", ".hcl"},
+		LineCommentStart:  []string{"#", "//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+	},
+	{
+		Name:             "GraphQL",
+		Extensions:       []string{".graphql", ".gql"},
+		LineCommentStart: []string{"#"},
+	},
+}
@@ -0,0 +1,160 @@
+package counter
+
+import "github.com/ousiassllc/linterly/internal/counter/funcs"
+
+// scriptLanguages は `#` `--` `;` など C 系以外のコメント構文を持つ言語一覧。
+// ブロックコメントの開始判定は行コメントより先に行われるため、
+// Lua の `--[[` や Julia の `#=` のように行コメントと接頭辞を共有していてもよい。
+var scriptLanguages = []Language{
+	{
+		Name:              "Python",
+		Extensions:        []string{".py"},
+		LineCommentStart:  []string{"#"},
+		BlockCommentStart: `"""`,
+		BlockCommentEnd:   `"""`,
+		Functions:         funcs.StyleIndent,
+	},
+	{
+		Name:              "Ruby",
+		Extensions:        []string{".rb"},
+		LineCommentStart:  []string{"#"},
+		BlockCommentStart: "=begin",
+		BlockCommentEnd:   "=end",
+		Functions:         funcs.StyleEnd,
+	},
+	{
+		Name:             "Crystal",
+		Extensions:       []string{".cr"},
+		LineCommentStart: []string{"#"},
+		Functions:        funcs.StyleEnd,
+	},
+	{
+		Name:             "Shell",
+		Extensions:       []string{".sh", ".bash", ".zsh"},
+		LineCommentStart: []string{"#"},
+		Functions:        funcs.StyleBraces,
+	},
+	{
+		Name:              "PowerShell",
+		Extensions:        []string{".ps1", ".psm1", ".psd1"},
+		LineCommentStart:  []string{"#"},
+		BlockCommentStart: "<#",
+		BlockCommentEnd:   "#>",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:              "Perl",
+		Extensions:        []string{".pl", ".pm"},
+		LineCommentStart:  []string{"#"},
+		BlockCommentStart: "=pod",
+		BlockCommentEnd:   "=cut",
+		Functions:         funcs.StyleBraces,
+	},
+	{
+		Name:             "R",
+		Extensions:       []string{".r", ".R"},
+		LineCommentStart: []string{"#"},
+	},
+	{
+		Name:              "Julia",
+		Extensions:        []string{".jl"},
+		LineCommentStart:  []string{"#"},
+		BlockCommentStart: "#=",
+		BlockCommentEnd:   "=#",
+	},
+	{
+		Name:              "Nim",
+		Extensions:        []string{".nim", ".nims"},
+		LineCommentStart:  []string{"#"},
+		BlockCommentStart: "#[",
+		BlockCommentEnd:   "]#",
+	},
+	{
+		Name:             "Elixir",
+		Extensions:       []string{".ex", ".exs"},
+		LineCommentStart: []string{"#"},
+	},
+	{
+		Name:             "Erlang",
+		Extensions:       []string{".erl", ".hrl"},
+		LineCommentStart: []string{"%"},
+	},
+	{
+		Name:              "Lua",
+		Extensions:        []string{".lua"},
+		LineCommentStart:  []string{"--"},
+		BlockCommentStart: "--[[",
+		BlockCommentEnd:   "]]",
+	},
+	{
+		Name:              "SQL",
+		Extensions:        []string{".sql"},
+		LineCommentStart:  []string{"--"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+	},
+	{
+		Name:              "Haskell",
+		Extensions:        []string{".hs"},
+		LineCommentStart:  []string{"--"},
+		BlockCommentStart: "{-",
+		BlockCommentEnd:   "-}",
+	},
+	{
+		Name:              "Elm",
+		Extensions:        []string{".elm"},
+		LineCommentStart:  []string{"--"},
+		BlockCommentStart: "{-",
+		BlockCommentEnd:   "-}",
+	},
+	{
+		Name:              "OCaml",
+		Extensions:        []string{".ml", ".mli"},
+		BlockCommentStart: "(*",
+		BlockCommentEnd:   "*)",
+	},
+	{
+		Name:              "F#",
+		Extensions:        []string{".fs", ".fsi", ".fsx"},
+		LineCommentStart:  []string{"//"},
+		BlockCommentStart: "(*",
+		BlockCommentEnd:   "*)",
+	},
+	{
+		Name:             "Clojure",
+		Extensions:       []string{".clj", ".cljs", ".cljc", ".edn"},
+		LineCommentStart: []string{";"},
+	},
+	{
+		Name:             "Lisp",
+		Extensions:       []string{".lisp", ".lsp", ".el", ".scm", ".rkt"},
+		LineCommentStart: []string{";"},
+	},
+	{
+		Name:             "Visual Basic",
+		Extensions:       []string{".vb", ".vbs"},
+		LineCommentStart: []string{"'"},
+	},
+	{
+		Name:             "Fortran",
+		Extensions:       []string{".f90", ".f95", ".f03", ".f08"},
+		LineCommentStart: []string{"!"},
+	},
+	{
+		Name:             "Assembly",
+		Extensions:       []string{".asm"},
+		LineCommentStart: []string{";"},
+	},
+	{
+		Name:             "Makefile",
+		Extensions:       []string{".mk"},
+		LineCommentStart: []string{"#"},
+	},
+	{
+		Name:              "CMake",
+		Extensions:        []string{".cmake"},
+		LineCommentStart:  []string{"#"},
+		BlockCommentStart: "#[[",
+		BlockCommentEnd:   "]]",
+	},
+}
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ousiassllc/linterly/internal/counter/funcs"
 )
 
 func TestDetectLanguage_Go(t *testing.T) {
@@ -59,6 +62,8 @@ func TestDetectLanguage_AllExtensions(t *testing.T) {
 		{".ts", "TypeScript"},
 		{".tsx", "TypeScript"},
 		{".mts", "TypeScript"},
+		{".cjs", "JavaScript"},
+		{".cts", "TypeScript"},
 		{".py", "Python"},
 		{".rb", "Ruby"},
 		{".java", "Java"},
@@ -80,6 +85,79 @@ func TestDetectLanguage_AllExtensions(t *testing.T) {
 		{".sh", "Shell"},
 		{".bash", "Shell"},
 		{".zsh", "Shell"},
+		{".scala", "Scala"},
+		{".groovy", "Groovy"},
+		{".gradle", "Groovy"},
+		{".cxx", "C++"},
+		{".hxx", "C++"},
+		{".cs", "C#"},
+		{".csx", "C#"},
+		{".m", "Objective-C"},
+		{".mm", "Objective-C"},
+		{".swift", "Swift"},
+		{".dart", "Dart"},
+		{".php", "PHP"},
+		{".sol", "Solidity"},
+		{".proto", "Protocol Buffers"},
+		{".zig", "Zig"},
+		{".cr", "Crystal"},
+		{".ps1", "PowerShell"},
+		{".psm1", "PowerShell"},
+		{".psd1", "PowerShell"},
+		{".pl", "Perl"},
+		{".pm", "Perl"},
+		{".r", "R"},
+		{".R", "R"},
+		{".jl", "Julia"},
+		{".nim", "Nim"},
+		{".nims", "Nim"},
+		{".ex", "Elixir"},
+		{".exs", "Elixir"},
+		{".erl", "Erlang"},
+		{".hrl", "Erlang"},
+		{".lua", "Lua"},
+		{".sql", "SQL"},
+		{".hs", "Haskell"},
+		{".elm", "Elm"},
+		{".ml", "OCaml"},
+		{".mli", "OCaml"},
+		{".fs", "F#"},
+		{".fsi", "F#"},
+		{".fsx", "F#"},
+		{".clj", "Clojure"},
+		{".cljs", "Clojure"},
+		{".cljc", "Clojure"},
+		{".edn", "Clojure"},
+		{".lisp", "Lisp"},
+		{".lsp", "Lisp"},
+		{".el", "Lisp"},
+		{".scm", "Lisp"},
+		{".rkt", "Lisp"},
+		{".vb", "Visual Basic"},
+		{".vbs", "Visual Basic"},
+		{".f90", "Fortran"},
+		{".f95", "Fortran"},
+		{".f03", "Fortran"},
+		{".f08", "Fortran"},
+		{".asm", "Assembly"},
+		{".mk", "Makefile"},
+		{".cmake", "CMake"},
+		{".vue", "Vue"},
+		{".svelte", "Svelte"},
+		{".less", "Less"},
+		{".yml", "YAML"},
+		{".yaml", "YAML"},
+		{".toml", "TOML"},
+		{".ini", "INI"},
+		{".tf", "HCL"},
+		{".tfvars", "HCL"},
+		{".hcl", "HCL"},
+		{".graphql", "GraphQL"},
+		{".gql", "GraphQL"},
+		{".astro", "Astro"},
+		{".md", "Markdown"},
+		{".markdown", "Markdown"},
+		{".ipynb", "Jupyter Notebook"},
 	}
 
 	for _, tt := range tests {
@@ -90,3 +168,72 @@ func TestDetectLanguage_AllExtensions(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectLanguage_ExtensionsAreUnique(t *testing.T) {
+	// 同じ拡張子が複数の言語に定義されていると後勝ちになり検出結果が不定になる
+	seen := make(map[string]string)
+	for _, lang := range languages {
+		for _, ext := range lang.Extensions {
+			prev, dup := seen[ext]
+			assert.False(t, dup, "extension %s is defined by both %s and %s", ext, prev, lang.Name)
+			seen[ext] = lang.Name
+		}
+	}
+	assert.GreaterOrEqual(t, len(languages), 40)
+}
+
+func TestLookupLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"go", "Go"},
+		{"golang", "Go"},
+		{"Python", "Python"},
+		{".py", "Python"},
+		{"ts", "TypeScript"},
+		{"javascript", "JavaScript"},
+		{"csharp", "C#"},
+		{"c++", "C++"},
+		{"bash", "Shell"},
+		{"shell", "Shell"},
+		{"R", "R"},
+		{"markdown", "Markdown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := LookupLanguage(tt.name)
+			require.NotNil(t, lang)
+			assert.Equal(t, tt.want, lang.Name)
+		})
+	}
+
+	assert.Nil(t, LookupLanguage(""))
+	assert.Nil(t, LookupLanguage("unknown-lang"))
+}
+
+func TestDetectLanguage_FunctionStyle(t *testing.T) {
+	tests := []struct {
+		path string
+		want funcs.Style
+	}{
+		{"main.go", funcs.StyleGo},
+		{"app.ts", funcs.StyleBraces},
+		{"Main.java", funcs.StyleBraces},
+		{"main.rs", funcs.StyleBraces},
+		{"main.zig", funcs.StyleBraces},
+		{"Token.sol", funcs.StyleBraces},
+		{"app.py", funcs.StyleIndent},
+		// 関数の検出に対応しない言語・データ形式
+		{"schema.proto", funcs.StyleNone},
+		{"config.yaml", funcs.StyleNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			lang := DetectLanguage(tt.path)
+			require.NotNil(t, lang)
+			assert.Equal(t, tt.want, lang.Functions)
+		})
+	}
+}
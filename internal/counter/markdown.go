@@ -0,0 +1,108 @@
+package counter
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/lines"
+)
+
+// codeFence は Markdown のフェンス付きコードブロックの開始行の情報。
+type codeFence struct {
+	char   byte   // '`' または '~'
+	length int    // フェンス文字の連続数（3 以上）
+	info   string // 言語指定（例: "go"）。省略時は空文字
+}
+
+// countMarkdown は Markdown ファイルをカウントする。
+// フェンス付きコードブロックは言語指定ごとのセクションとして、その言語のコメント構文で判定する。
+// コード行数にはコードブロック内のコード行のみを含め、本文（markdown セクション）は含めない。
+func countMarkdown(r io.Reader, lang *Language, widths *lines.WidthChecker) (*LineCount, error) {
+	lr := lines.NewReader(r)
+	total, code := 0, 0
+
+	sc := newSectionCounter()
+	sc.enter(SectionMarkdown, lang)
+
+	var open *codeFence
+	for lr.Scan() {
+		total++
+		line := lr.Text()
+		widths.Add(line)
+
+		if open == nil {
+			if fence, ok := parseCodeFence(line); ok {
+				open = &fence
+				name := strings.ToLower(fence.info)
+				if name == "" {
+					name = SectionCode
+				}
+				sc.enter(name, LookupLanguage(fence.info))
+				sc.add(false) // フェンス行はコードブロックに含めるがコード行ではない
+				continue
+			}
+			sc.add(sc.classify(line))
+			continue
+		}
+
+		if isClosingFence(line, *open) {
+			sc.add(false)
+			open = nil
+			sc.enter(SectionMarkdown, lang)
+			continue
+		}
+		if sc.add(sc.classify(line)) {
+			code++
+		}
+	}
+
+	if err := lr.Err(); err != nil {
+		return nil, err
+	}
+	return sc.result(total, code, lr.Longest()), nil
+}
+
+// parseCodeFence は line がコードブロックの開始フェンス（``` または ~~~）であれば、その情報を返す。
+func parseCodeFence(line string) (codeFence, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 {
+		return codeFence{}, false
+	}
+	char := trimmed[0]
+	if char != '`' && char != '~' {
+		return codeFence{}, false
+	}
+
+	n := 0
+	for n < len(trimmed) && trimmed[n] == char {
+		n++
+	}
+	if n < 3 {
+		return codeFence{}, false
+	}
+
+	info := strings.TrimSpace(trimmed[n:])
+	// バッククォートのフェンスは言語指定にバッククォートを含められない（CommonMark 仕様）
+	if char == '`' && strings.Contains(info, "`") {
+		return codeFence{}, false
+	}
+	// "{.python}" や "python title=x" のような指定は先頭の単語だけを言語として扱う
+	if fields := strings.Fields(info); len(fields) > 0 {
+		info = strings.Trim(fields[0], "{}.")
+	}
+	return codeFence{char: char, length: n, info: info}, true
+}
+
+// isClosingFence は line が open に対応する終了フェンスであるかを判定する。
+func isClosingFence(line string, open codeFence) bool {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < open.length {
+		return false
+	}
+	for i := 0; i < len(trimmed); i++ {
+		if trimmed[i] != open.char {
+			return false
+		}
+	}
+	return true
+}
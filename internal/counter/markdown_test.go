@@ -0,0 +1,83 @@
+package counter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountFile_CodeOnly_Markdown(t *testing.T) {
+	lc, err := CountFile("testdata/sample.md", Options{CountMode: config.CountModeCodeOnly})
+	require.NoError(t, err)
+	assert.Equal(t, 20, lc.TotalLines)
+	// go: package main, func main() {} = 2（コメント・空行・フェンス行を除外）
+	// python: print("hi") = 1
+	// 言語指定なし: plain block = 1
+	// 本文（markdown セクション）はコード行に含めない
+	assert.Equal(t, 4, lc.CodeLines)
+
+	require.Len(t, lc.Sections, 4)
+	assert.Equal(t, Section{Name: SectionMarkdown, TotalLines: 7, CodeLines: 2}, lc.Sections[0])
+	assert.Equal(t, Section{Name: "go", TotalLines: 6, CodeLines: 2}, lc.Sections[1])
+	assert.Equal(t, Section{Name: "python", TotalLines: 4, CodeLines: 1}, lc.Sections[2])
+	assert.Equal(t, Section{Name: SectionCode, TotalLines: 3, CodeLines: 1}, lc.Sections[3])
+}
+
+func TestCountFile_AllMode_Markdown(t *testing.T) {
+	lc, err := CountFile("testdata/sample.md", Options{CountMode: config.CountModeAll})
+	require.NoError(t, err)
+	assert.Equal(t, 20, lc.TotalLines)
+	assert.Equal(t, 20, lc.CodeLines) // all モードでは同じ
+}
+
+func TestCountMarkdown_Fences(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		code int
+	}{
+		// 閉じフェンスは開始フェンス以上の長さが必要（内側の ``` はブロックの内容）
+		{"LongerOpeningFence", "````go\nx := 1\n```\ny := 2\n````\n", 3},
+		// ~~~ と ``` は対応しない
+		{"MismatchedFenceChar", "~~~\na\n```\nb\n~~~\n", 3},
+		// 4 スペース以上のインデントはフェンスではない
+		{"IndentedNotFence", "    ```\ncode?\n", 0},
+		// 閉じられないコードブロックはファイル末尾まで続く
+		{"Unclosed", "```sh\necho 1\necho 2\n", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc, err := countMarkdown(strings.NewReader(tt.src), LookupLanguage("markdown"), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, lc.CodeLines)
+		})
+	}
+}
+
+func TestParseCodeFence_Info(t *testing.T) {
+	tests := []struct {
+		line string
+		info string
+		ok   bool
+	}{
+		{"```go", "go", true},
+		{"``` python title=\"a.py\"", "python", true},
+		{"```{.ruby}", "ruby", true},
+		{"~~~~", "", true},
+		{"``", "", false},
+		{"```a`b", "", false},
+		{"text", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			fence, ok := parseCodeFence(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.info, fence.info)
+		})
+	}
+}
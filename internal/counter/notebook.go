@@ -0,0 +1,99 @@
+package counter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/lines"
+)
+
+// notebook は Jupyter Notebook（.ipynb）のうちカウントに必要な部分。
+type notebook struct {
+	Cells []struct {
+		CellType string          `json:"cell_type"`
+		Source   json.RawMessage `json:"source"` // 文字列または文字列の配列
+	} `json:"cells"`
+	Metadata struct {
+		LanguageInfo struct {
+			Name          string `json:"name"`
+			FileExtension string `json:"file_extension"`
+		} `json:"language_info"`
+		Kernelspec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+	} `json:"metadata"`
+}
+
+// countNotebook は Jupyter Notebook の JSON を解析し、セルのソース行をカウントする。
+// 行数は JSON の行数ではなく全セルのソース行数とし、コード行数にはコードセルのコード行のみを含める。
+// コードセルはカーネル言語のコメント構文で判定し、code / markdown / raw のセクションに分けて集計する。
+// JSON として解析できない場合は言語不明のテキストとしてカウントする。
+func countNotebook(r io.Reader, _ *Language, widths *lines.WidthChecker) (*LineCount, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var nb notebook
+	if json.Unmarshal(data, &nb) != nil {
+		return countCodeOnly(bytes.NewReader(data), nil, widths)
+	}
+
+	kernel := nb.kernelLanguage()
+	sc := newSectionCounter()
+	total, code, longest := 0, 0, 0
+	for _, cell := range nb.Cells {
+		switch cell.CellType {
+		case "code":
+			sc.enter(SectionCode, kernel)
+		case "markdown":
+			sc.enter(SectionMarkdown, LookupLanguage("markdown"))
+		default:
+			sc.enter(SectionRaw, nil)
+		}
+
+		lr := lines.NewReader(strings.NewReader(cellSource(cell.Source)))
+		for lr.Scan() {
+			total++
+			line := lr.Text()
+			widths.Add(line)
+			if sc.add(sc.classify(line)) && sc.current == SectionCode {
+				code++
+			}
+		}
+		if err := lr.Err(); err != nil {
+			return nil, err
+		}
+		longest = max(longest, lr.Longest())
+	}
+	return sc.result(total, code, longest), nil
+}
+
+// kernelLanguage は Notebook のメタデータからコードセルの言語を検出する。
+func (nb *notebook) kernelLanguage() *Language {
+	for _, name := range []string{
+		nb.Metadata.LanguageInfo.FileExtension,
+		nb.Metadata.LanguageInfo.Name,
+		nb.Metadata.Kernelspec.Language,
+	} {
+		if lang := LookupLanguage(name); lang != nil {
+			return lang
+		}
+	}
+	return nil
+}
+
+// cellSource はセルの source（文字列または文字列の配列）を1つの文字列に連結する。
+func cellSource(raw json.RawMessage) string {
+	var lines []string
+	if json.Unmarshal(raw, &lines) == nil {
+		return strings.Join(lines, "")
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return ""
+}
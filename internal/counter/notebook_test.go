@@ -0,0 +1,48 @@
+package counter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountFile_CodeOnly_Notebook(t *testing.T) {
+	lc, err := CountFile("testdata/sample.ipynb", Options{CountMode: config.CountModeCodeOnly})
+	require.NoError(t, err)
+	// JSON の行数ではなくセルのソース行数: markdown(3) + code(4) + code(1) = 8
+	assert.Equal(t, 8, lc.TotalLines)
+	// コードセルのうちコメント・空行を除いた行: import, df =, df.head() = 3
+	assert.Equal(t, 3, lc.CodeLines)
+
+	require.Len(t, lc.Sections, 2)
+	assert.Equal(t, Section{Name: SectionMarkdown, TotalLines: 3, CodeLines: 2}, lc.Sections[0])
+	assert.Equal(t, Section{Name: SectionCode, TotalLines: 5, CodeLines: 3}, lc.Sections[1])
+}
+
+func TestCountFile_AllMode_Notebook(t *testing.T) {
+	lc, err := CountFile("testdata/sample.ipynb", Options{CountMode: config.CountModeAll})
+	require.NoError(t, err)
+	assert.Equal(t, 8, lc.TotalLines)
+	assert.Equal(t, 8, lc.CodeLines)
+}
+
+func TestCountNotebook_KernelLanguage(t *testing.T) {
+	// R カーネルでは # がコメント、language_info がなければ kernelspec を使う
+	src := `{"cells":[{"cell_type":"code","source":["# c\n","x <- 1"]}],
+		"metadata":{"kernelspec":{"language":"R"}}}`
+	lc, err := countNotebook(strings.NewReader(src), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lc.TotalLines)
+	assert.Equal(t, 1, lc.CodeLines)
+}
+
+func TestCountNotebook_InvalidJSONFallsBackToText(t *testing.T) {
+	lc, err := countNotebook(strings.NewReader("not json\n\nat all\n"), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, lc.TotalLines)
+	assert.Equal(t, 2, lc.CodeLines)
+	assert.Nil(t, lc.Sections)
+}
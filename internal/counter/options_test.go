@@ -0,0 +1,129 @@
+package counter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountFile_MaxLineLength(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":  "package main\n\n// " + strings.Repeat("長", 20) + "\nfunc main() {}\n",
+		"App.vue":  "<template>\n  <p>" + strings.Repeat("x", 50) + "</p>\n</template>\n",
+		"short.py": "x = 1\n",
+		// 出力（base64 の画像）ではなく、セルのソース行を通し番号で計測する
+		"nb.ipynb": `{"cells":[{"cell_type":"code","source":["x = 1\n","` + strings.Repeat("y", 50) +
+			`"],"outputs":[{"data":{"image/png":"` + strings.Repeat("A", 500) + `"}}]}]}`,
+	}
+	for name, src := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0644))
+	}
+
+	opts := Options{CountMode: config.CountModeCodeOnly, MaxLineLength: 40}
+
+	lc, err := CountFile(filepath.Join(dir, "main.go"), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, lc.LongLines)
+	assert.Equal(t, 3+40, lc.MaxLineWidth)
+	assert.Equal(t, 2, lc.CodeLines, "行幅の計測がカウント結果に影響しないこと")
+
+	lc, err = CountFile(filepath.Join(dir, "App.vue"), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, lc.LongLines)
+
+	lc, err = CountFile(filepath.Join(dir, "nb.ipynb"), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, lc.LongLines)
+	assert.Equal(t, 50, lc.MaxLineWidth)
+
+	lc, err = CountFile(filepath.Join(dir, "short.py"), opts)
+	require.NoError(t, err)
+	assert.Empty(t, lc.LongLines)
+	assert.Equal(t, 5, lc.MaxLineWidth)
+
+	// 上限が 0 の場合は計測しない
+	lc, err = CountFile(filepath.Join(dir, "main.go"), Options{CountMode: config.CountModeAll})
+	require.NoError(t, err)
+	assert.Empty(t, lc.LongLines)
+	assert.Zero(t, lc.MaxLineWidth)
+}
+
+func TestCountFile_MaxLinesPerFunction(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":   "package main\n\nfunc main() {\n\t// comment\n\n\tprintln()\n}\n",
+		"broken.go": "package main\n\nfunc main() {\n",
+		"main.py":   "def main():\n    pass\n",
+		"main.lua":  "function main()\nend\n",
+	}
+	for name, src := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0644))
+	}
+
+	opts := Options{CountMode: config.CountModeAll, MaxLinesPerFunction: 50}
+
+	lc, err := CountFile(filepath.Join(dir, "main.go"), opts)
+	require.NoError(t, err)
+	require.Len(t, lc.Functions, 1)
+	assert.Equal(t, "main", lc.Functions[0].Name)
+	assert.Equal(t, 3, lc.Functions[0].Line)
+	assert.Equal(t, 5, lc.Functions[0].TotalLines)
+	assert.Equal(t, 3, lc.Functions[0].CodeLines)
+	assert.Equal(t, 7, lc.TotalLines, "関数の検出がカウント結果に影響しないこと")
+
+	// 構文エラーのファイルは関数を検出しないが、行数はカウントする
+	lc, err = CountFile(filepath.Join(dir, "broken.go"), opts)
+	require.NoError(t, err)
+	assert.Empty(t, lc.Functions)
+	assert.Equal(t, 3, lc.TotalLines)
+
+	// Go 以外は言語ごとの検出方式で推定する
+	lc, err = CountFile(filepath.Join(dir, "main.py"), opts)
+	require.NoError(t, err)
+	require.Len(t, lc.Functions, 1)
+	assert.Equal(t, 2, lc.Functions[0].TotalLines)
+
+	// 関数の検出に対応していない言語
+	lc, err = CountFile(filepath.Join(dir, "main.lua"), opts)
+	require.NoError(t, err)
+	assert.Empty(t, lc.Functions)
+
+	// 上限が 0 の場合は検出しない
+	lc, err = CountFile(filepath.Join(dir, "main.go"), Options{CountMode: config.CountModeAll})
+	require.NoError(t, err)
+	assert.Empty(t, lc.Functions)
+}
+
+func TestCountFile_MaxDeclarationsPerFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"types.go": "package main\n\ntype A struct{}\n\ntype B struct{}\n\nfunc (A) M() {}\n",
+		"empty.ts": "const x = 1;\n",
+		"main.lua": "function main()\nend\n",
+	}
+	for name, src := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0644))
+	}
+	opts := Options{CountMode: config.CountModeAll, MaxDeclarationsPerFile: 10}
+
+	lc, err := CountFile(filepath.Join(dir, "types.go"), opts)
+	require.NoError(t, err)
+	assert.Len(t, lc.Declarations, 2, "メソッドは宣言として数えない")
+	assert.Empty(t, lc.Functions, "max_lines_per_function が 0 の場合は関数を記録しない")
+
+	lc, err = CountFile(filepath.Join(dir, "empty.ts"), opts)
+	require.NoError(t, err)
+	assert.NotNil(t, lc.Declarations)
+	assert.Empty(t, lc.Declarations)
+
+	// 宣言の検出に対応していない言語は nil
+	lc, err = CountFile(filepath.Join(dir, "main.lua"), opts)
+	require.NoError(t, err)
+	assert.Nil(t, lc.Declarations)
+}
@@ -0,0 +1,62 @@
+package counter
+
+import "github.com/ousiassllc/linterly/internal/counter/syntax"
+
+// セクション名。
+const (
+	// Vue/Svelte/Astro のブロック
+	SectionTemplate = "template"
+	SectionScript   = "script"
+	SectionStyle    = "style"
+
+	// Markdown の本文・Jupyter Notebook のセル種別
+	SectionMarkdown = "markdown"
+	SectionCode     = "code"
+	SectionRaw      = "raw"
+)
+
+// sectionCounter はセクションごとの行数と、現在のセクションの判定状態を保持する。
+// 同名のセクションが複数回現れた場合（複数の <script> ブロック等）は1つに合算する。
+type sectionCounter struct {
+	sections   []Section
+	index      map[string]int
+	classifier *syntax.Classifier
+	current    string
+}
+
+func newSectionCounter() *sectionCounter {
+	return &sectionCounter{index: make(map[string]int)}
+}
+
+// enter はセクションを切り替え、lang のコメント構文で判定を始める。
+// lang が nil の場合は空行以外をすべてコード行とみなす。
+func (sc *sectionCounter) enter(name string, lang *Language) {
+	sc.current = name
+	sc.classifier = syntax.NewClassifier(lang.syntax())
+}
+
+// classify は line が現在のセクションの言語でコード行であるかを返す。
+func (sc *sectionCounter) classify(line string) bool {
+	return sc.classifier.IsCode(line)
+}
+
+// add は現在のセクションに1行を加算し、isCode をそのまま返す。
+func (sc *sectionCounter) add(isCode bool) bool {
+	i, ok := sc.index[sc.current]
+	if !ok {
+		i = len(sc.sections)
+		sc.index[sc.current] = i
+		sc.sections = append(sc.sections, Section{Name: sc.current})
+	}
+
+	sc.sections[i].TotalLines++
+	if isCode {
+		sc.sections[i].CodeLines++
+	}
+	return isCode
+}
+
+// result はファイル全体の集計値とセクションごとの内訳から LineCount を返す。
+func (sc *sectionCounter) result(total, code, longest int) *LineCount {
+	return &LineCount{TotalLines: total, CodeLines: code, Sections: sc.sections, LongestLine: longest}
+}
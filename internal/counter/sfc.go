@@ -0,0 +1,114 @@
+package counter
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/ousiassllc/linterly/internal/lines"
+)
+
+// langAttrPattern は <script lang="ts"> などの lang 属性を抽出する。
+var langAttrPattern = regexp.MustCompile(`\blang\s*=\s*["']?([A-Za-z0-9]+)`)
+
+// defaultSectionExt は lang 属性が省略された場合に使うセクションごとの拡張子。
+var defaultSectionExt = map[string]string{
+	SectionTemplate: ".html",
+	SectionScript:   ".js",
+	SectionStyle:    ".css",
+}
+
+// countComponent は Vue/Svelte/Astro のファイルをセクションごとに言語を切り替えてカウントする。
+// <script>/<style> ブロックの外側はテンプレート（HTML）として扱う。
+// Astro の先頭 `---` で囲まれたフロントマターは TypeScript の script セクションとして扱う。
+func countComponent(r io.Reader, lang *Language, widths *lines.WidthChecker) (*LineCount, error) {
+	lr := lines.NewReader(r)
+	total, code := 0, 0
+
+	sc := newSectionCounter()
+	sc.enter(SectionTemplate, sectionLanguage(SectionTemplate, ""))
+
+	inFrontmatter := false
+	for lr.Scan() {
+		total++
+		line := lr.Text()
+		widths.Add(line)
+		trimmed := strings.TrimSpace(line)
+
+		// Astro フロントマター（ファイル先頭の --- から次の --- まで）
+		// 区切り行の --- はコード行として扱う
+		if lang.Name == "Astro" && trimmed == "---" {
+			if total == 1 {
+				inFrontmatter = true
+				sc.enter(SectionScript, sectionLanguage(SectionScript, "ts"))
+				sc.add(true)
+				code++
+				continue
+			}
+			if inFrontmatter {
+				inFrontmatter = false
+				sc.add(true)
+				code++
+				sc.enter(SectionTemplate, sectionLanguage(SectionTemplate, ""))
+				continue
+			}
+		}
+
+		if !inFrontmatter && sc.current == SectionTemplate {
+			for _, name := range []string{SectionScript, SectionStyle} {
+				if isOpeningTag(trimmed, name) {
+					sc.enter(name, sectionLanguage(name, tagLang(trimmed)))
+					break
+				}
+			}
+		}
+
+		// <script>/</script> 等のタグ行はコメント判定を行わずコード行とする
+		isTag := sc.current != SectionTemplate && isTagLine(trimmed, sc.current)
+		if sc.add(isTag || sc.classify(line)) {
+			code++
+		}
+
+		if sc.current != SectionTemplate && strings.Contains(trimmed, "</"+sc.current+">") {
+			sc.enter(SectionTemplate, sectionLanguage(SectionTemplate, ""))
+		}
+	}
+
+	if err := lr.Err(); err != nil {
+		return nil, err
+	}
+	return sc.result(total, code, lr.Longest()), nil
+}
+
+// sectionLanguage はセクション名と lang 属性からコメント判定に使う言語を返す。
+// 未対応の lang 属性（pug 等）の場合は nil を返す。
+func sectionLanguage(name, langAttr string) *Language {
+	ext := defaultSectionExt[name]
+	if langAttr != "" {
+		ext = "." + strings.ToLower(langAttr)
+	}
+	return DetectLanguage("section" + ext)
+}
+
+// isOpeningTag は trimmed が <name> または <name ...> で始まるかを判定する。
+func isOpeningTag(trimmed, name string) bool {
+	rest, ok := strings.CutPrefix(trimmed, "<"+name)
+	if !ok {
+		return false
+	}
+	return rest == "" || rest[0] == '>' || rest[0] == ' ' || rest[0] == '\t'
+}
+
+// isTagLine は trimmed がセクションの開始タグまたは終了タグを含むかを判定する。
+func isTagLine(trimmed, name string) bool {
+	return isOpeningTag(trimmed, name) || strings.Contains(trimmed, "</"+name+">")
+}
+
+// tagLang は開始タグの lang 属性の値を返す。属性がない場合は空文字を返す。
+func tagLang(tag string) string {
+	m := langAttrPattern.FindStringSubmatch(tag)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
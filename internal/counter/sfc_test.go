@@ -0,0 +1,83 @@
+package counter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountFile_CodeOnly_Vue(t *testing.T) {
+	lc, err := CountFile("testdata/sample.vue", Options{CountMode: config.CountModeCodeOnly})
+	require.NoError(t, err)
+	assert.Equal(t, 17, lc.TotalLines)
+	// template: <template>, <div>, </template> = 3（HTML コメント・空行除外）
+	// script: <script>, const msg, </script> = 3（// と /* */ を除外）
+	// style: <style>, .a, </style> = 3（SCSS の // を除外）
+	assert.Equal(t, 9, lc.CodeLines)
+
+	require.Len(t, lc.Sections, 3)
+	assert.Equal(t, Section{Name: SectionTemplate, TotalLines: 6, CodeLines: 3}, lc.Sections[0])
+	assert.Equal(t, Section{Name: SectionScript, TotalLines: 7, CodeLines: 3}, lc.Sections[1])
+	assert.Equal(t, Section{Name: SectionStyle, TotalLines: 4, CodeLines: 3}, lc.Sections[2])
+}
+
+func TestCountFile_AllMode_VueKeepsSections(t *testing.T) {
+	lc, err := CountFile("testdata/sample.vue", Options{CountMode: config.CountModeAll})
+	require.NoError(t, err)
+	assert.Equal(t, 17, lc.TotalLines)
+	assert.Equal(t, 17, lc.CodeLines) // all モードでは同じ
+	assert.Len(t, lc.Sections, 3)
+}
+
+func TestCountSections_Svelte(t *testing.T) {
+	// Svelte は <script>/<style> の外側がすべてテンプレート
+	src := "<script>\n  // c\n  let n = 0;\n</script>\n\n<button>{n}</button>\n<!-- c -->\n"
+	lc, err := countComponent(strings.NewReader(src), DetectLanguage("App.svelte"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 7, lc.TotalLines)
+	assert.Equal(t, 3+1, lc.CodeLines)
+	assert.Equal(t, []Section{
+		{Name: SectionScript, TotalLines: 4, CodeLines: 3},
+		{Name: SectionTemplate, TotalLines: 3, CodeLines: 1},
+	}, lc.Sections)
+}
+
+func TestCountSections_AstroFrontmatter(t *testing.T) {
+	src := "---\n// c\nconst title = 'x';\n---\n<h1>{title}</h1>\n<style>\n/* c */\nh1 { color: red; }\n</style>\n"
+	lc, err := countComponent(strings.NewReader(src), DetectLanguage("index.astro"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 9, lc.TotalLines)
+	assert.Equal(t, 7, lc.CodeLines)
+	assert.Equal(t, []Section{
+		{Name: SectionScript, TotalLines: 4, CodeLines: 3},
+		{Name: SectionTemplate, TotalLines: 1, CodeLines: 1},
+		{Name: SectionStyle, TotalLines: 4, CodeLines: 3},
+	}, lc.Sections)
+}
+
+func TestCountSections_SingleLineScriptAndUnknownLang(t *testing.T) {
+	// 1行で完結する <script> と、未対応の lang 属性（pug）は全行コード扱い
+	src := "<template lang=\"pug\">\n</template>\n<script>export default {}</script>\n<div></div>\n"
+	lc, err := countComponent(strings.NewReader(src), DetectLanguage("App.vue"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Section{
+		{Name: SectionTemplate, TotalLines: 3, CodeLines: 3},
+		{Name: SectionScript, TotalLines: 1, CodeLines: 1},
+	}, lc.Sections)
+}
+
+func TestCountFile_SectionCounterReportsLongestLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "App.vue")
+	src := "<template>\r\n  <p>{{ message }}</p>\r\n</template>\r\n<script>\r\nexport default {}\r\n</script>\r\n"
+	require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+
+	lc, err := CountFile(path, Options{CountMode: config.CountModeAll})
+	require.NoError(t, err)
+	assert.Equal(t, 6, lc.TotalLines)
+	assert.Equal(t, len("  <p>{{ message }}</p>"), lc.LongestLine)
+}
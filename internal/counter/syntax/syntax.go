@@ -0,0 +1,117 @@
+// Package syntax は言語ごとのコメント構文と、それに基づいて1行ずつコード行を判定する Classifier を提供する。
+// 行数のカウント（counter）と関数の検出（funcs）で共通に使う。
+package syntax
+
+import "strings"
+
+// Syntax は言語のコメント構文。
+type Syntax struct {
+	Name        string   // 言語名（言語固有の扱いの判定に使う）
+	LineComment []string // 行コメントの開始記号（例: "//", "#"）
+	BlockStart  string   // ブロックコメントの開始記号（例: "/*"）
+	BlockEnd    string   // ブロックコメントの終了記号（例: "*/"）
+}
+
+// Classifier は1行ずつコード行かどうかを判定する。
+// ブロックコメントの内外の状態を行をまたいで保持する。
+type Classifier struct {
+	lang    *Syntax
+	inBlock bool
+	// Python docstring のためのトラッカー
+	isPython bool
+}
+
+// NewClassifier は lang のコメント構文で判定する Classifier を返す。
+// lang が nil の場合は空行以外をすべてコード行とみなす。
+func NewClassifier(lang *Syntax) *Classifier {
+	return &Classifier{
+		lang:     lang,
+		isPython: lang != nil && lang.Name == "Python",
+	}
+}
+
+// IsCode は line がコード行（コメント・空行以外）であるかを返す。
+func (c *Classifier) IsCode(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	lang := c.lang
+
+	// 空行チェック
+	if trimmed == "" {
+		return false
+	}
+
+	// 対応言語がない場合、すべてコード行として扱う
+	if lang == nil {
+		return true
+	}
+
+	// ブロックコメント内にいる場合
+	if c.inBlock {
+		if containsBlockEnd(trimmed, lang, c.isPython) {
+			c.inBlock = false
+		}
+		return false
+	}
+
+	// ブロックコメント開始チェック
+	if lang.BlockStart != "" && containsBlockStart(trimmed, lang, c.isPython) {
+		// 同じ行で開始・終了する場合
+		if !sameLineBlockComment(trimmed, lang, c.isPython) {
+			c.inBlock = true
+		}
+		return false
+	}
+
+	// 行コメントチェック
+	return !isLineComment(trimmed, lang)
+}
+
+// isLineComment は行が行コメントであるかを判定する。
+func isLineComment(trimmed string, lang *Syntax) bool {
+	for _, prefix := range lang.LineComment {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsBlockStart は行がブロックコメント開始を含むかを判定する。
+func containsBlockStart(trimmed string, lang *Syntax, isPython bool) bool {
+	if isPython {
+		return strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, `'''`)
+	}
+	return strings.HasPrefix(trimmed, lang.BlockStart)
+}
+
+// containsBlockEnd は行がブロックコメント終了を含むかを判定する。
+func containsBlockEnd(trimmed string, lang *Syntax, isPython bool) bool {
+	if isPython {
+		// 開始行と同じ行の場合は sameLineBlockComment で処理済み
+		// ここでは終了行のみチェック
+		return strings.HasSuffix(trimmed, `"""`) || strings.HasSuffix(trimmed, `'''`)
+	}
+	return strings.Contains(trimmed, lang.BlockEnd)
+}
+
+// sameLineBlockComment は同じ行でブロックコメントが開始・終了するかを判定する。
+func sameLineBlockComment(trimmed string, lang *Syntax, isPython bool) bool {
+	if isPython {
+		// """...""" or '''...'''
+		for _, delim := range []string{`"""`, `'''`} {
+			if strings.HasPrefix(trimmed, delim) {
+				rest := trimmed[len(delim):]
+				if strings.Contains(rest, delim) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if strings.HasPrefix(trimmed, lang.BlockStart) {
+		rest := trimmed[len(lang.BlockStart):]
+		return strings.Contains(rest, lang.BlockEnd)
+	}
+	return false
+}
@@ -3,7 +3,9 @@ package i18n
 import (
 	"embed"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
@@ -23,6 +25,11 @@ func IsSupportedLanguage(lang string) bool {
 	return ok
 }
 
+// SupportedLanguages は対応する言語コードを名前順で返す。
+func SupportedLanguages() []string {
+	return slices.Sorted(maps.Keys(supportedLanguages))
+}
+
 // Translator はメッセージ翻訳を行う。
 type Translator struct {
 	lang     string
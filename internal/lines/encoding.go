@@ -0,0 +1,95 @@
+package lines
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+	"golang.org/x/text/transform"
+)
+
+// Encoding は BOM から判定したテキストのエンコーディング。
+type Encoding string
+
+const (
+	EncodingNone    Encoding = ""         // BOM なし（UTF-8 として扱う）
+	EncodingUTF8    Encoding = "utf-8"    // UTF-8（BOM 付き）
+	EncodingUTF16LE Encoding = "utf-16le" // UTF-16 リトルエンディアン
+	EncodingUTF16BE Encoding = "utf-16be" // UTF-16 ビッグエンディアン
+	EncodingUTF32LE Encoding = "utf-32le" // UTF-32 リトルエンディアン
+	EncodingUTF32BE Encoding = "utf-32be" // UTF-32 ビッグエンディアン
+)
+
+// boms は BOM のバイト列とエンコーディングの対応。
+// UTF-32LE の BOM は UTF-16LE の BOM で始まるため、UTF-32 を先に判定する。
+var boms = []struct {
+	bom []byte
+	enc Encoding
+}{
+	{[]byte{0x00, 0x00, 0xFE, 0xFF}, EncodingUTF32BE},
+	{[]byte{0xFF, 0xFE, 0x00, 0x00}, EncodingUTF32LE},
+	{[]byte{0xEF, 0xBB, 0xBF}, EncodingUTF8},
+	{[]byte{0xFE, 0xFF}, EncodingUTF16BE},
+	{[]byte{0xFF, 0xFE}, EncodingUTF16LE},
+}
+
+// maxBOMSize は BOM の最大バイト数。
+const maxBOMSize = 4
+
+// DetectBOM はバイト列先頭の BOM からエンコーディングを判定する。
+// BOM がない場合は EncodingNone を返す。
+func DetectBOM(head []byte) Encoding {
+	for _, b := range boms {
+		if bytes.HasPrefix(head, b.bom) {
+			return b.enc
+		}
+	}
+	return EncodingNone
+}
+
+// Decode は r の先頭の BOM を判定し、UTF-8 に変換して BOM を取り除いた Reader を返す。
+// BOM がない場合は r の内容をそのまま返す。
+func Decode(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(maxBOMSize)
+
+	enc := DetectBOM(head)
+	if enc == EncodingUTF8 {
+		_, _ = br.Discard(3)
+		return br
+	}
+	if dec := decoder(enc); dec != nil {
+		return transform.NewReader(br, dec)
+	}
+	return br
+}
+
+// DecodeBytes は Decode のバイト列版。ファイル先頭のみを読み取った場合など、
+// 末尾の文字が途中で切れていてもエラーにせず変換できた部分を返す。
+func DecodeBytes(b []byte) []byte {
+	dec := decoder(DetectBOM(b))
+	if dec == nil {
+		return bytes.TrimPrefix(b, []byte{0xEF, 0xBB, 0xBF})
+	}
+	out, _ := io.ReadAll(transform.NewReader(bytes.NewReader(b), dec))
+	return out
+}
+
+// decoder は UTF-16 / UTF-32 のデコーダーを返す。それ以外は nil を返す。
+// デコーダーは先頭の BOM を取り除く。
+func decoder(enc Encoding) *encoding.Decoder {
+	switch enc {
+	case EncodingUTF16LE:
+		return unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder()
+	case EncodingUTF16BE:
+		return unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder()
+	case EncodingUTF32LE:
+		return utf32.UTF32(utf32.LittleEndian, utf32.ExpectBOM).NewDecoder()
+	case EncodingUTF32BE:
+		return utf32.UTF32(utf32.BigEndian, utf32.ExpectBOM).NewDecoder()
+	}
+	return nil
+}
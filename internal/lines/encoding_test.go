@@ -0,0 +1,76 @@
+package lines
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+func TestDetectBOM(t *testing.T) {
+	tests := []struct {
+		name string
+		head []byte
+		enc  Encoding
+	}{
+		{"UTF-8", []byte{0xEF, 0xBB, 0xBF, 'a'}, EncodingUTF8},
+		{"UTF-16LE", []byte{0xFF, 0xFE, 'a', 0x00}, EncodingUTF16LE},
+		{"UTF-16BE", []byte{0xFE, 0xFF, 0x00, 'a'}, EncodingUTF16BE},
+		{"UTF-32LE", []byte{0xFF, 0xFE, 0x00, 0x00}, EncodingUTF32LE},
+		{"UTF-32BE", []byte{0x00, 0x00, 0xFE, 0xFF}, EncodingUTF32BE},
+		{"BOM なし", []byte("package main"), EncodingNone},
+		{"空", nil, EncodingNone},
+		{"NUL を含むバイナリ", []byte{0x00, 0x01, 0x02}, EncodingNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.enc, DetectBOM(tt.head))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	const text = "// 日本語\r\nx = 1\n"
+
+	utf16le, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String(text)
+	require.NoError(t, err)
+	utf16be, err := unicode.UTF16(unicode.BigEndian, unicode.UseBOM).NewEncoder().String(text)
+	require.NoError(t, err)
+	utf32le, err := utf32.UTF32(utf32.LittleEndian, utf32.UseBOM).NewEncoder().String(text)
+	require.NoError(t, err)
+	utf32be, err := utf32.UTF32(utf32.BigEndian, utf32.UseBOM).NewEncoder().String(text)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"BOM なし", text},
+		{"UTF-8 BOM", "\xEF\xBB\xBF" + text},
+		{"UTF-16LE", utf16le},
+		{"UTF-16BE", utf16be},
+		{"UTF-32LE", utf32le},
+		{"UTF-32BE", utf32be},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := io.ReadAll(Decode(strings.NewReader(tt.src)))
+			require.NoError(t, err)
+			assert.Equal(t, text, string(out))
+		})
+	}
+}
+
+func TestDecodeBytes_TruncatedHead(t *testing.T) {
+	// ファイル先頭だけを読み取った場合、末尾の文字が途中で切れていても変換できる
+	src, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String("// Code generated. DO NOT EDIT.\nabc")
+	require.NoError(t, err)
+
+	out := DecodeBytes([]byte(src)[:len(src)-1])
+	assert.True(t, strings.HasPrefix(string(out), "// Code generated. DO NOT EDIT.\nab"))
+	assert.Equal(t, "abc", string(DecodeBytes([]byte("\xEF\xBB\xBFabc"))))
+}
@@ -0,0 +1,112 @@
+// Package lines は行単位の読み取りと、行ごとの表示幅の計測を行う。
+// いずれも \n・\r\n・単独の \r を1つの改行として扱う。
+package lines
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// readChunkSize は Reader が一度に読み取るバイト数。
+const readChunkSize = 32 * 1024
+
+// Reader はバイト単位で行を分割するリーダー。
+// bufio.Scanner と異なり行長の上限がなく、\n・\r\n・単独の \r をいずれも改行として扱う。
+// 末尾が改行で終わらない最終行も1行として数え、最長行の長さ（文字数）を副産物として記録する。
+type Reader struct {
+	r       io.Reader
+	buf     []byte // 読み取り済みで未処理のデータ
+	chunk   []byte
+	line    []byte
+	longest int
+	err     error
+	eof     bool
+	skipLF  bool // 直前の行が \r で終わった場合、続く \n を読み飛ばす
+}
+
+// NewReader は r から行を読み取る Reader を返す。
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r, chunk: make([]byte, readChunkSize)}
+}
+
+// Scan は次の行を読み取る。行がない場合やエラーの場合は false を返す。
+func (lr *Reader) Scan() bool {
+	lr.line = lr.line[:0]
+	hasLine := false
+	for {
+		if len(lr.buf) == 0 {
+			if lr.eof || lr.err != nil {
+				break
+			}
+			lr.fill()
+			continue
+		}
+
+		if lr.skipLF {
+			lr.skipLF = false
+			if lr.buf[0] == '\n' {
+				lr.buf = lr.buf[1:]
+				continue
+			}
+		}
+
+		hasLine = true
+		i := bytes.IndexAny(lr.buf, "\r\n")
+		if i < 0 {
+			lr.line = append(lr.line, lr.buf...)
+			lr.buf = lr.buf[:0]
+			continue
+		}
+		lr.line = append(lr.line, lr.buf[:i]...)
+		lr.skipLF = lr.buf[i] == '\r'
+		lr.buf = lr.buf[i+1:]
+		break
+	}
+
+	if !hasLine {
+		return false
+	}
+	if n := runeCount(lr.line); n > lr.longest {
+		lr.longest = n
+	}
+	return true
+}
+
+// fill は下位のリーダーから次のチャンクを読み取る。
+func (lr *Reader) fill() {
+	n, err := lr.r.Read(lr.chunk)
+	lr.buf = lr.chunk[:n]
+	if errors.Is(err, io.EOF) {
+		lr.eof = true
+	} else if err != nil {
+		lr.err = err
+	}
+}
+
+// Text は直前に読み取った行を改行文字を除いて返す。
+func (lr *Reader) Text() string {
+	return string(lr.line)
+}
+
+// Err は読み取り中に発生した io.EOF 以外のエラーを返す。
+func (lr *Reader) Err() error {
+	return lr.err
+}
+
+// Longest はこれまでに読み取った行のうち最長の行の文字数（rune 数）を返す。
+func (lr *Reader) Longest() int {
+	return lr.longest
+}
+
+// runeCount は UTF-8 の継続バイト以外のバイト数を rune 数として返す。
+// 不正なバイト列も1バイト1文字として扱う。
+func runeCount(b []byte) int {
+	n := 0
+	for _, c := range b {
+		if c&0xC0 != 0x80 {
+			n++
+		}
+	}
+	return n
+}
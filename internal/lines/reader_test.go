@@ -0,0 +1,57 @@
+package lines
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readLines は Reader で読み取った全行を返すヘルパー。
+func readLines(t *testing.T, r io.Reader) ([]string, *Reader) {
+	t.Helper()
+	lr := NewReader(r)
+	var lines []string
+	for lr.Scan() {
+		lines = append(lines, lr.Text())
+	}
+	require.NoError(t, lr.Err())
+	return lines, lr
+}
+
+func TestLineReader_Endings(t *testing.T) {
+	lines, _ := readLines(t, strings.NewReader("a\nb\r\nc\rd\r\r\ne"))
+	assert.Equal(t, []string{"a", "b", "c", "d", "", "e"}, lines)
+}
+
+func TestLineReader_CRLFAcrossReads(t *testing.T) {
+	// \r と \n が別々の Read で返されても1つの改行として扱う
+	lines, _ := readLines(t, iotest.OneByteReader(strings.NewReader("ab\r\ncd\r\n\r\n")))
+	assert.Equal(t, []string{"ab", "cd", ""}, lines)
+}
+
+func TestLineReader_LongLineAcrossChunks(t *testing.T) {
+	long := strings.Repeat("x", readChunkSize*3+7)
+	lines, lr := readLines(t, strings.NewReader("short\n"+long+"\nend"))
+	require.Len(t, lines, 3)
+	assert.Equal(t, long, lines[1])
+	assert.Equal(t, len(long), lr.Longest())
+}
+
+func TestLineReader_LongestCountsRunes(t *testing.T) {
+	_, lr := readLines(t, strings.NewReader("abc\nあいうえお\r\nab\n"))
+	assert.Equal(t, 5, lr.Longest())
+}
+
+func TestLineReader_ReadError(t *testing.T) {
+	errRead := errors.New("read failed")
+	lr := NewReader(io.MultiReader(strings.NewReader("a\n"), iotest.ErrReader(errRead)))
+	require.True(t, lr.Scan())
+	assert.Equal(t, "a", lr.Text())
+	assert.False(t, lr.Scan())
+	assert.ErrorIs(t, lr.Err(), errRead)
+}
@@ -0,0 +1,82 @@
+package lines
+
+import "golang.org/x/text/width"
+
+// TabWidth はタブ文字の展開幅（タブストップの間隔）。
+const TabWidth = 4
+
+// WidthChecker は行ごとの表示幅を計測する。行は Reader で分割したもの（改行文字を含まない）を
+// 先頭から順に Add で渡す。行番号は Add で渡した行の順番（1 始まり）とする。
+type WidthChecker struct {
+	max    int
+	line   int // 直前に計測した行の行番号
+	widest int
+	long   []int
+}
+
+// NewWidthChecker は max を上限とする WidthChecker を返す。max が 0 以下の場合は幅の計測のみ行う。
+func NewWidthChecker(max int) *WidthChecker {
+	return &WidthChecker{max: max}
+}
+
+// Add は次の1行の表示幅を計測する。c が nil の場合は何もしない。
+// 不正な UTF-8 のバイトは1バイト1文字として扱う。
+func (c *WidthChecker) Add(line string) {
+	if c == nil {
+		return
+	}
+	c.line++
+	col := 0
+	for _, r := range line {
+		if r == '\t' {
+			col += TabWidth - col%TabWidth
+			continue
+		}
+		col += RuneWidth(r)
+	}
+	c.widest = max(c.widest, col)
+	if c.max > 0 && col > c.max {
+		c.long = append(c.long, c.line)
+	}
+}
+
+// LongLines は表示幅が上限を超えた行の行番号（1 始まり、昇順）を返す。
+func (c *WidthChecker) LongLines() []int {
+	return c.long
+}
+
+// Widest は最も幅の広い行の表示幅を返す。
+func (c *WidthChecker) Widest() int {
+	return c.widest
+}
+
+// RuneWidth は1文字の表示幅を返す。
+// 東アジアの全角・広幅文字は 2、制御文字と結合文字は 0、それ以外は 1 とする。
+func RuneWidth(r rune) int {
+	if r < 0x20 || r == 0x7f {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	if isCombining(r) {
+		return 0
+	}
+	return 1
+}
+
+// isCombining は r が直前の文字と結合して表示される文字（結合文字・ゼロ幅文字）かを返す。
+func isCombining(r rune) bool {
+	switch {
+	case r >= 0x0300 && r <= 0x036F: // 結合分音記号
+		return true
+	case r == 0x200B || r == 0x200C || r == 0x200D || r == 0xFEFF: // ゼロ幅文字・BOM
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F: // 異体字セレクタ
+		return true
+	case r == 0x3099 || r == 0x309A: // 結合用の濁点・半濁点
+		return true
+	}
+	return false
+}
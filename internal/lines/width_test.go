@@ -0,0 +1,82 @@
+package lines
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// check は s を Reader で行に分割して計測した WidthChecker を返すヘルパー。
+func check(max int, s string) *WidthChecker {
+	c := NewWidthChecker(max)
+	lr := NewReader(strings.NewReader(s))
+	for lr.Scan() {
+		c.Add(lr.Text())
+	}
+	return c
+}
+
+func TestChecker_LongLines(t *testing.T) {
+	c := check(5, "abc\nabcdef\nabcde\n\nabcdefgh")
+	assert.Equal(t, []int{2, 5}, c.LongLines())
+	assert.Equal(t, 8, c.Widest())
+}
+
+func TestChecker_LineEndings(t *testing.T) {
+	// \r\n・単独の \r・\n のいずれも1つの改行として数える（行の分割は Reader と同じ）
+	c := check(2, "a\r\nbbb\rc\r\r\nddd\n")
+	assert.Equal(t, []int{2, 5}, c.LongLines())
+}
+
+func TestChecker_Tabs(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int
+	}{
+		{"行頭のタブ", "\tx", TabWidth + 1},
+		{"タブストップへ揃える", "ab\tx", TabWidth + 1},
+		{"タブストップ上のタブ", "abcd\tx", TabWidth*2 + 1},
+		{"連続したタブ", "\t\tx", TabWidth*2 + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.width, check(0, tt.line).Widest())
+		})
+	}
+}
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int
+	}{
+		{"ASCII", "// comment", 10},
+		{"全角文字", "// 日本語のコメント", 3 + 8*2},
+		{"全角英数・記号", "ＡＢＣ（）", 10},
+		{"半角カナ", "ｱｲｳ", 3},
+		{"絵文字", "🎉", 2},
+		{"結合文字", "e\u0301", 1},
+		{"BOM", "\ufeffabc", 3},
+		{"不正な UTF-8", "a\xff\xfeb", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.width, check(0, tt.line).Widest())
+		})
+	}
+}
+
+func TestChecker_NoLimit(t *testing.T) {
+	c := check(0, strings.Repeat("x", 1000))
+	assert.Empty(t, c.LongLines())
+	assert.Equal(t, 1000, c.Widest())
+}
+
+func TestChecker_Nil(t *testing.T) {
+	var c *WidthChecker
+	c.Add("abc")
+	assert.Nil(t, c)
+}
@@ -3,6 +3,7 @@ package reporter
 import (
 	"encoding/json"
 	"io"
+	"math"
 
 	"github.com/ousiassllc/linterly/internal/analyzer"
 )
@@ -17,22 +18,61 @@ type jsonOutput struct {
 	Warnings []string     `json:"warnings"`
 	Results  []jsonResult `json:"results"`
 	Summary  jsonSummary  `json:"summary"`
+	// Skipped はチェック対象から外したファイル（skip_generated による自動生成ファイルと、
+	// report_skipped_binary 有効時のバイナリファイル。ない場合は出力しない）
+	Skipped []jsonSkipped `json:"skipped,omitempty"`
+}
+
+type jsonSkipped struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
 }
 
 type jsonResult struct {
 	Path      string `json:"path"`
 	Type      string `json:"type"`
+	Section   string `json:"section,omitempty"`
 	Lines     int    `json:"lines"`
 	Limit     int    `json:"limit"`
 	Threshold int    `json:"threshold"`
 	Severity  string `json:"severity"`
+	// Sections はセクションごとの行数の内訳（Vue/Svelte/Astro, Markdown, Notebook のファイルのみ）
+	Sections []jsonSection `json:"sections,omitempty"`
+	// LongestLine は最長行の文字数（ファイルのみ）
+	LongestLine int `json:"longest_line,omitempty"`
+	// LineNumbers は上限を超えた行の行番号（line_length のみ）
+	LineNumbers []int `json:"line_numbers,omitempty"`
+	// Function と Line は関数名と開始行（function のみ）
+	Function string `json:"function,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	// Test はテストファイルかどうか（file のみ）
+	Test bool `json:"test,omitempty"`
+	// Headroom は上限までの残り行数（budget のみ）
+	Headroom *int `json:"headroom,omitempty"`
+}
+
+type jsonSection struct {
+	Name  string `json:"name"`
+	Lines int    `json:"lines"`
 }
 
 type jsonSummary struct {
 	Errors   int `json:"errors"`
 	Warnings int `json:"warnings"`
-	Passed   int `json:"passed"`
-	Total    int `json:"total"`
+	// Info は rules.severity が info のルールの違反の件数
+	Info   int `json:"info"`
+	Passed int `json:"passed"`
+	Total  int `json:"total"`
+	// テストファイル（test_patterns にマッチ）と本番コードの件数・行数、およびその比（テスト / 本番）
+	TestFiles       int     `json:"test_files"`
+	TestLines       int     `json:"test_lines"`
+	ProductionFiles int     `json:"production_files"`
+	ProductionLines int     `json:"production_lines"`
+	TestRatio       float64 `json:"test_ratio"`
+	// Skipped はスキップ理由ごとの件数
+	Skipped map[string]int `json:"skipped,omitempty"`
+	// Failure は終了コードが 1 になる理由（該当する場合のみ出力）
+	Failure *analyzer.Failure `json:"failure,omitempty"`
 }
 
 // Report は分析結果を JSON 形式で出力する。
@@ -47,24 +87,55 @@ func (r *JSONReporter) Report(report *analyzer.AnalysisReport, warnings []string
 		Warnings: w,
 		Results:  make([]jsonResult, 0, len(report.Results)),
 		Summary: jsonSummary{
-			Errors:   report.Errors,
-			Warnings: report.Warnings,
-			Passed:   report.Passed,
-			Total:    report.Errors + report.Warnings + report.Passed,
+			Errors:          report.Errors,
+			Warnings:        report.Warnings,
+			Info:            report.Infos,
+			Passed:          report.Passed,
+			Total:           report.Errors + report.Warnings + report.Infos + report.Passed,
+			TestFiles:       report.TestFiles,
+			TestLines:       report.TestLines,
+			ProductionFiles: report.ProductionFiles,
+			ProductionLines: report.ProductionLines,
+			TestRatio:       math.Round(report.TestRatio()*100) / 100,
+			Failure:         report.Failure,
 		},
 	}
 
 	for _, result := range report.Results {
+		var sections []jsonSection
+		for _, sec := range result.Sections {
+			sections = append(sections, jsonSection{Name: sec.Name, Lines: sec.Lines})
+		}
+		var headroom *int
+		if result.Type == analyzer.TypeBudget {
+			headroom = &result.Headroom
+		}
 		output.Results = append(output.Results, jsonResult{
-			Path:      result.Path,
-			Type:      result.Type,
-			Lines:     result.Lines,
-			Limit:     result.Limit,
-			Threshold: result.Threshold,
-			Severity:  string(result.Severity),
+			Path:        result.Path,
+			Type:        result.Type,
+			Section:     result.Section,
+			Lines:       result.Lines,
+			Limit:       result.Limit,
+			Threshold:   result.Threshold,
+			Severity:    string(result.Severity),
+			Sections:    sections,
+			LongestLine: result.LongestLine,
+			LineNumbers: result.LineNumbers,
+			Function:    result.Function,
+			Line:        result.Line,
+			Headroom:    headroom,
+			Test:        result.Test,
 		})
 	}
 
+	for _, s := range report.Skipped {
+		output.Skipped = append(output.Skipped, jsonSkipped{Path: s.Path, Reason: s.Reason})
+		if output.Summary.Skipped == nil {
+			output.Summary.Skipped = make(map[string]int)
+		}
+		output.Summary.Skipped[s.Reason]++
+	}
+
 	encoder := json.NewEncoder(r.writer)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(output)
@@ -242,3 +242,47 @@ func TestTextReporter_NoColor_Field(t *testing.T) {
 	assert.False(t, strings.Contains(output, "\033[31m")) // カラーなし
 	assert.Contains(t, output, "a.go")
 }
+
+func TestReporter_SectionResult(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+
+	report := &analyzer.AnalysisReport{
+		Results: []analyzer.Result{
+			{Path: "src/App.vue", Type: analyzer.TypeSection, Section: "script", Lines: 150, Limit: 100, Threshold: 110, Severity: analyzer.SeverityError},
+		},
+		Errors: 1,
+	}
+
+	var text bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &text).Report(report, nil))
+	assert.Contains(t, text.String(), "ERROR src/App.vue <script> (150 lines, limit: 100)")
+
+	var out bytes.Buffer
+	require.NoError(t, NewReporter(FormatJSON, nil, &out).Report(report, nil))
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.Equal(t, "section", output.Results[0].Type)
+	assert.Equal(t, "script", output.Results[0].Section)
+}
+
+func TestJSONReporter_FileSections(t *testing.T) {
+	var buf bytes.Buffer
+	report := &analyzer.AnalysisReport{
+		Results: []analyzer.Result{
+			{Path: "notes.ipynb", Type: analyzer.TypeFile, Lines: 20, Limit: 300, Threshold: 330, Severity: analyzer.SeverityPass,
+				Sections: []analyzer.SectionLines{{Name: "markdown", Lines: 12}, {Name: "code", Lines: 20}}},
+			{Path: "main.go", Type: analyzer.TypeFile, Lines: 8, Limit: 300, Threshold: 330, Severity: analyzer.SeverityPass},
+		},
+		Passed: 2,
+	}
+	require.NoError(t, NewReporter(FormatJSON, nil, &buf).Report(report, nil))
+
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, []jsonSection{{Name: "markdown", Lines: 12}, {Name: "code", Lines: 20}}, output.Results[0].Sections)
+	// セクションのないファイルでは sections キー自体を出力しない
+	assert.Equal(t, 1, strings.Count(buf.String(), `"sections"`))
+}
@@ -0,0 +1,280 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/analyzer"
+	"github.com/ousiassllc/linterly/internal/i18n"
+	"github.com/ousiassllc/linterly/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSkippedReport() *analyzer.AnalysisReport {
+	report := newTestReport()
+	report.Skipped = []scanner.SkippedFile{
+		{Path: "api/api.pb.go", Reason: scanner.SkipReasonGenerated},
+		{Path: "api/client_gen.go", Reason: scanner.SkipReasonGenerated},
+	}
+	return report
+}
+
+func TestTextReporter_SkippedGenerated(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &buf).Report(newSkippedReport(), nil))
+	assert.Contains(t, buf.String(), "Skipped 2 generated file(s)")
+
+	trJa, err := i18n.New("ja")
+	require.NoError(t, err)
+
+	buf.Reset()
+	require.NoError(t, NewReporter(FormatText, trJa, &buf).Report(newSkippedReport(), nil))
+	assert.Contains(t, buf.String(), "自動生成ファイル 2 件をスキップしました")
+}
+
+func TestTextReporter_NoSkippedLine(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &buf).Report(newTestReport(), nil))
+	assert.NotContains(t, buf.String(), "Skipped")
+}
+
+func TestJSONReporter_Skipped(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewReporter(FormatJSON, nil, &buf).Report(newSkippedReport(), nil))
+
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, map[string]int{"generated": 2}, output.Summary.Skipped)
+	assert.Equal(t, jsonSkipped{Path: "api/api.pb.go", Reason: "generated"}, output.Skipped[0])
+
+	// スキップがない場合はキー自体を出力しない
+	buf.Reset()
+	require.NoError(t, NewReporter(FormatJSON, nil, &buf).Report(newTestReport(), nil))
+	assert.NotContains(t, buf.String(), `"skipped"`)
+}
+
+func TestReporter_LineLengthResult(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	report := &analyzer.AnalysisReport{
+		Results: []analyzer.Result{
+			{Path: "src/main.go", Type: analyzer.TypeLineLength, Lines: 140, Limit: 100, Threshold: 110,
+				Severity: analyzer.SeverityError, LineNumbers: []int{12, 40}},
+		},
+		Errors: 1,
+	}
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+	var text bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &text).Report(report, nil))
+	assert.Contains(t, text.String(), "ERROR src/main.go (2 line(s) longer than 100 columns, widest: 140; lines: 12, 40)")
+
+	trJa, err := i18n.New("ja")
+	require.NoError(t, err)
+	text.Reset()
+	require.NoError(t, NewReporter(FormatText, trJa, &text).Report(report, nil))
+	assert.Contains(t, text.String(), "ERROR src/main.go (2 行が 100 桁を超過, 最大: 140, 行: 12, 40)")
+
+	var out bytes.Buffer
+	require.NoError(t, NewReporter(FormatJSON, nil, &out).Report(report, nil))
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.Equal(t, "line_length", output.Results[0].Type)
+	assert.Equal(t, []int{12, 40}, output.Results[0].LineNumbers)
+}
+
+func TestTextReporter_SkippedBinary(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+
+	report := newTestReport()
+	report.Skipped = []scanner.SkippedFile{
+		{Path: "assets/logo.png", Reason: scanner.SkipReasonBinary},
+		{Path: "api/api.pb.go", Reason: scanner.SkipReasonGenerated},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &buf).Report(report, nil))
+	output := buf.String()
+	assert.Contains(t, output, "Skipped 1 generated file(s)")
+	assert.Contains(t, output, "Skipped 1 binary file(s)")
+	// バイナリは誤判定の確認用にパスを一覧表示し、自動生成ファイルは件数のみ
+	assert.Contains(t, output, "  SKIP  assets/logo.png (binary)")
+	assert.NotContains(t, output, "api/api.pb.go")
+}
+
+func TestReporter_FunctionResult(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	report := &analyzer.AnalysisReport{
+		Results: []analyzer.Result{
+			{Path: "src/server.go", Type: analyzer.TypeFunction, Function: "(*Server).Handle", Line: 42,
+				Lines: 80, Limit: 50, Threshold: 55, Severity: analyzer.SeverityError},
+		},
+		Errors: 1,
+	}
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+	var text bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &text).Report(report, nil))
+	assert.Contains(t, text.String(), "ERROR src/server.go:42 (*Server).Handle (80 lines, limit: 50)")
+
+	var out bytes.Buffer
+	require.NoError(t, NewReporter(FormatJSON, nil, &out).Report(report, nil))
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.Equal(t, "function", output.Results[0].Type)
+	assert.Equal(t, "(*Server).Handle", output.Results[0].Function)
+	assert.Equal(t, 42, output.Results[0].Line)
+}
+
+func TestTextReporter_DeclarationsResult(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+	report := &analyzer.AnalysisReport{
+		Results: []analyzer.Result{
+			{Path: "src/types.go", Type: analyzer.TypeDeclarations, Lines: 35, Limit: 10, Threshold: 11, Severity: analyzer.SeverityError},
+		},
+		Errors: 1,
+	}
+	var text bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &text).Report(report, nil))
+	assert.Contains(t, text.String(), "ERROR src/types.go (35 declarations, limit: 10)")
+}
+
+func TestReporter_BudgetResult(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	report := &analyzer.AnalysisReport{
+		Results: []analyzer.Result{
+			{Path: "services/billing/**", Type: analyzer.TypeBudget, Lines: 26000, Limit: 25000, Threshold: 27500,
+				Severity: analyzer.SeverityWarn, Headroom: -1000},
+			{Path: "**", Type: analyzer.TypeBudget, Lines: 500000, Limit: 500000, Threshold: 550000,
+				Severity: analyzer.SeverityPass},
+		},
+		Warnings: 1,
+		Passed:   1,
+	}
+
+	trJa, err := i18n.New("ja")
+	require.NoError(t, err)
+	var text bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, trJa, &text).Report(report, nil))
+	assert.Contains(t, text.String(), "WARN  services/billing/** (合計 26000 行, 予算: 25000, 残り: -1000)")
+
+	var out bytes.Buffer
+	require.NoError(t, NewReporter(FormatJSON, nil, &out).Report(report, nil))
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.Equal(t, "budget", output.Results[0].Type)
+	require.NotNil(t, output.Results[1].Headroom)
+	// 残りが 0 の場合も headroom を出力する
+	assert.Equal(t, 0, *output.Results[1].Headroom)
+}
+
+func TestJSONReporter_TestStats(t *testing.T) {
+	report := newTestReport()
+	report.Results[0].Test = true
+	report.TestFiles, report.TestLines = 1, 325
+	report.ProductionFiles, report.ProductionLines = 2, 550
+
+	var buf bytes.Buffer
+	require.NoError(t, NewReporter(FormatJSON, nil, &buf).Report(report, nil))
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.True(t, output.Results[0].Test)
+	assert.False(t, output.Results[1].Test)
+	assert.Equal(t, 1, output.Summary.TestFiles)
+	assert.Equal(t, 325, output.Summary.TestLines)
+	assert.Equal(t, 2, output.Summary.ProductionFiles)
+	assert.Equal(t, 550, output.Summary.ProductionLines)
+	// 比は小数第2位に丸める
+	assert.Equal(t, 0.59, output.Summary.TestRatio)
+}
+
+func TestReporter_InfoResult(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	report := &analyzer.AnalysisReport{
+		Results: []analyzer.Result{
+			{Path: "src/", Type: analyzer.TypeDirectory, Lines: 2500, Limit: 2000, Threshold: 2200, Severity: analyzer.SeverityInfo},
+			{Path: "src/a.go", Type: analyzer.TypeFile, Lines: 100, Limit: 300, Threshold: 330, Severity: analyzer.SeverityPass},
+		},
+		Infos:  1,
+		Passed: 1,
+	}
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+	var text bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &text).Report(report, nil))
+	assert.Contains(t, text.String(), "  INFO  src/ (2500 lines, limit: 2000)")
+	assert.Contains(t, text.String(), "Results: 0 error(s), 0 warning(s), 1 info, 1 passed")
+
+	var out bytes.Buffer
+	require.NoError(t, NewReporter(FormatJSON, nil, &out).Report(report, nil))
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.Equal(t, "info", output.Results[0].Severity)
+	assert.Equal(t, 1, output.Summary.Info)
+	assert.Equal(t, 2, output.Summary.Total)
+}
+
+func TestReporter_Failure(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	report := &analyzer.AnalysisReport{
+		Results: []analyzer.Result{
+			{Path: "src/a.go", Type: analyzer.TypeFile, Lines: 310, Limit: 300, Threshold: 330, Severity: analyzer.SeverityWarn},
+		},
+		Warnings: 1,
+		Failure:  &analyzer.Failure{Reason: analyzer.FailReasonMaxWarnings, Count: 1, Limit: 0},
+	}
+
+	tr, err := i18n.New("en")
+	require.NoError(t, err)
+	var text bytes.Buffer
+	require.NoError(t, NewReporter(FormatText, tr, &text).Report(report, nil))
+	assert.Contains(t, text.String(), "Failed: 1 warning(s) exceed the maximum of 0 (max_warnings)")
+
+	var out bytes.Buffer
+	require.NoError(t, NewReporter(FormatJSON, nil, &out).Report(report, nil))
+	assert.Contains(t, out.String(), `"failure": {`)
+	var output jsonOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	require.NotNil(t, output.Summary.Failure)
+	assert.Equal(t, "max_warnings", output.Summary.Failure.Reason)
+
+	// 失敗しない場合は理由を出力しない
+	report.Failure = nil
+	text.Reset()
+	require.NoError(t, NewReporter(FormatText, tr, &text).Report(report, nil))
+	assert.NotContains(t, text.String(), "Failed:")
+	out.Reset()
+	require.NoError(t, NewReporter(FormatJSON, nil, &out).Report(report, nil))
+	assert.NotContains(t, out.String(), `"failure"`)
+}
+
+func TestFormatLineNumbers(t *testing.T) {
+	assert.Equal(t, "3", formatLineNumbers([]int{3}))
+	assert.Equal(t, "1, 2, 3, 4, 5, 6, 7, 8, 9, 10, ...",
+		formatLineNumbers([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}))
+}
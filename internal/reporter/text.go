@@ -3,9 +3,12 @@ package reporter
 import (
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/ousiassllc/linterly/internal/analyzer"
 	"github.com/ousiassllc/linterly/internal/i18n"
+	"github.com/ousiassllc/linterly/internal/scanner"
 )
 
 // TextReporter はテキスト形式で結果を出力する。
@@ -16,7 +19,7 @@ type TextReporter struct {
 }
 
 // Report は分析結果をテキスト形式で出力する。
-// テキスト出力では violation（warn/error）のみ表示し、pass は表示しない。
+// テキスト出力では violation（info/warn/error）のみ表示し、pass は表示しない。
 func (r *TextReporter) Report(report *analyzer.AnalysisReport, warnings []string) error {
 	// ignore 重複警告を先に出力
 	for _, w := range warnings {
@@ -35,7 +38,7 @@ func (r *TextReporter) Report(report *analyzer.AnalysisReport, warnings []string
 	for _, result := range report.Results {
 		switch result.Severity {
 		case analyzer.SeverityWarn:
-			line := r.translator.T("check.warn", result.Path, result.Lines, result.Limit)
+			line := r.formatResult("check.warn", result)
 			if !r.noColor {
 				line = colorYellow("  " + line)
 			} else {
@@ -43,8 +46,17 @@ func (r *TextReporter) Report(report *analyzer.AnalysisReport, warnings []string
 			}
 			fmt.Fprintln(r.writer, line)
 			hasViolation = true
+		case analyzer.SeverityInfo:
+			line := r.formatResult("check.info", result)
+			if !r.noColor {
+				line = colorCyan("  " + line)
+			} else {
+				line = "  " + line
+			}
+			fmt.Fprintln(r.writer, line)
+			hasViolation = true
 		case analyzer.SeverityError:
-			line := r.translator.T("check.error", result.Path, result.Lines, result.Limit)
+			line := r.formatResult("check.error", result)
 			if !r.noColor {
 				line = colorRed("  " + line)
 			} else {
@@ -61,11 +73,87 @@ func (r *TextReporter) Report(report *analyzer.AnalysisReport, warnings []string
 
 	// サマリー
 	summary := r.translator.T("check.summary", report.Errors, report.Warnings, report.Passed)
+	if report.Infos > 0 {
+		summary = r.translator.T("check.summary_info", report.Errors, report.Warnings, report.Infos, report.Passed)
+	}
 	fmt.Fprintln(r.writer, summary)
+	r.reportFailure(report.Failure)
+
+	if n := report.SkippedCount(scanner.SkipReasonGenerated); n > 0 {
+		fmt.Fprintln(r.writer, r.translator.T("check.skipped_generated", n))
+	}
+	r.reportSkippedBinary(report)
 
 	return nil
 }
 
+// reportFailure は終了コードが 1 になる理由を出力する。
+func (r *TextReporter) reportFailure(f *analyzer.Failure) {
+	if f == nil {
+		return
+	}
+	var line string
+	if f.Reason == analyzer.FailReasonMaxWarnings {
+		line = r.translator.T("check.fail_max_warnings", f.Count, f.Limit)
+	} else {
+		line = r.translator.T("check.fail_"+f.Reason, f.Count)
+	}
+	if !r.noColor {
+		line = colorRed(line)
+	}
+	fmt.Fprintln(r.writer, line)
+}
+
+// reportSkippedBinary はバイナリとしてスキップしたファイルを一覧表示する。
+// report_skipped_binary: true の場合のみ記録されるため、誤判定の確認用にパスもすべて出力する。
+func (r *TextReporter) reportSkippedBinary(report *analyzer.AnalysisReport) {
+	n := report.SkippedCount(scanner.SkipReasonBinary)
+	if n == 0 {
+		return
+	}
+	fmt.Fprintln(r.writer, r.translator.T("check.skipped_binary", n))
+	for _, s := range report.Skipped {
+		if s.Reason == scanner.SkipReasonBinary {
+			fmt.Fprintln(r.writer, "  "+r.translator.T("check.skip_binary", s.Path))
+		}
+	}
+}
+
+// formatResult は結果1件分のメッセージを返す。
+// key は "check.info" / "check.warn" / "check.error" のいずれかで、結果の種別に応じたメッセージキーを選ぶ。
+func (r *TextReporter) formatResult(key string, result analyzer.Result) string {
+	switch result.Type {
+	case analyzer.TypeSection:
+		return r.translator.T(key+"_section", result.Path, result.Section, result.Lines, result.Limit)
+	case analyzer.TypeLineLength:
+		return r.translator.T(key+"_line_length", result.Path, len(result.LineNumbers), result.Limit, result.Lines,
+			formatLineNumbers(result.LineNumbers))
+	case analyzer.TypeDeclarations:
+		return r.translator.T(key+"_declarations", result.Path, result.Lines, result.Limit)
+	case analyzer.TypeBudget:
+		return r.translator.T(key+"_budget", result.Path, result.Lines, result.Limit, result.Headroom)
+	case analyzer.TypeFunction:
+		return r.translator.T(key+"_function", result.Path, result.Line, result.Function, result.Lines, result.Limit)
+	}
+	return r.translator.T(key, result.Path, result.Lines, result.Limit)
+}
+
+// maxListedLines はテキスト出力で列挙する行番号の上限。
+const maxListedLines = 10
+
+// formatLineNumbers は行番号をカンマ区切りで返す。maxListedLines 件を超える分は "..." で省略する。
+func formatLineNumbers(nums []int) string {
+	parts := make([]string, 0, min(len(nums), maxListedLines)+1)
+	for i, n := range nums {
+		if i == maxListedLines {
+			parts = append(parts, "...")
+			break
+		}
+		parts = append(parts, strconv.Itoa(n))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // ANSI カラーコード
 func colorRed(s string) string {
 	return "\033[31m" + s + "\033[0m"
@@ -74,3 +162,7 @@ func colorRed(s string) string {
 func colorYellow(s string) string {
 	return "\033[33m" + s + "\033[0m"
 }
+
+func colorCyan(s string) string {
+	return "\033[36m" + s + "\033[0m"
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/ousiassllc/linterly/internal/lines"
 )
 
 // binarySniffSize はバイナリ判定で読み取る先頭バイト数。
@@ -51,38 +53,34 @@ var binaryExtensions = map[string]bool{
 	".db": true, ".sqlite": true, ".sqlite3": true,
 }
 
-// isBinary はファイルがバイナリかどうかを2段階で判定する。
-// 第1段階: 拡張子チェック（I/O なし）
-// 第2段階: ファイル先頭の null バイト検出
-func isBinary(path string) (bool, error) {
-	if isBinaryExtension(path) {
-		return true, nil
-	}
-	return isBinaryContent(path)
-}
-
 // isBinaryExtension は拡張子が既知のバイナリ形式かを判定する。
 func isBinaryExtension(path string) bool {
 	ext := strings.ToLower(filepath.Ext(path))
 	return binaryExtensions[ext]
 }
 
-// isBinaryContent はファイル先頭を読み取り、null バイトの有無でバイナリ判定する。
-func isBinaryContent(path string) (bool, error) {
+// isBinaryHead はファイル先頭のバイト列に null バイトが含まれるかを判定する。
+// UTF-16 / UTF-32 のテキストは null バイトを含むため、BOM があればテキストとみなす。
+func isBinaryHead(head []byte) bool {
+	if lines.DetectBOM(head) != lines.EncodingNone {
+		return false
+	}
+	return bytes.Contains(head, []byte{0x00})
+}
+
+// readHead はファイル先頭の最大 binarySniffSize バイトを読み取る。
+// バイナリ判定と自動生成ファイル判定で共有する。
+func readHead(path string) ([]byte, error) {
 	f, err := os.Open(path)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	defer f.Close()
 
 	buf := make([]byte, binarySniffSize)
-	n, err := f.Read(buf)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return false, err
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
 	}
-	if n == 0 {
-		return false, nil
-	}
-
-	return bytes.Contains(buf[:n], []byte{0x00}), nil
+	return buf[:n], nil
 }
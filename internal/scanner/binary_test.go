@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -40,7 +41,7 @@ func TestScan_SkipsBinaryFiles(t *testing.T) {
 		Ignore:          []string{},
 	}
 
-	result, err := Scan(tmpDir, cfg)
+	result, err := scanRoot(t, tmpDir, cfg)
 	require.NoError(t, err)
 
 	paths := filePaths(result)
@@ -73,7 +74,7 @@ func TestScan_SkipsBinaryByExtension(t *testing.T) {
 		Ignore:          []string{},
 	}
 
-	result, err := Scan(tmpDir, cfg)
+	result, err := scanRoot(t, tmpDir, cfg)
 	require.NoError(t, err)
 
 	paths := filePaths(result)
@@ -112,7 +113,7 @@ func TestScan_NullByteDetection(t *testing.T) {
 		Ignore:          []string{},
 	}
 
-	result, err := Scan(tmpDir, cfg)
+	result, err := scanRoot(t, tmpDir, cfg)
 	require.NoError(t, err)
 
 	paths := filePaths(result)
@@ -145,7 +146,7 @@ func TestScan_ScriptFilesNotSkipped(t *testing.T) {
 		Ignore:          []string{},
 	}
 
-	result, err := Scan(tmpDir, cfg)
+	result, err := scanRoot(t, tmpDir, cfg)
 	require.NoError(t, err)
 
 	paths := filePaths(result)
@@ -190,7 +191,7 @@ func TestScan_ImageFilesSkipped(t *testing.T) {
 		Ignore:          []string{},
 	}
 
-	result, err := Scan(tmpDir, cfg)
+	result, err := scanRoot(t, tmpDir, cfg)
 	require.NoError(t, err)
 
 	paths := filePaths(result)
@@ -255,27 +256,28 @@ func TestIsBinaryExtension(t *testing.T) {
 	}
 }
 
-func TestIsBinaryContent(t *testing.T) {
+func TestIsBinaryHead_ReadHead(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	// null バイトを含むファイル
-	binaryPath := filepath.Join(tmpDir, "binary")
-	require.NoError(t, os.WriteFile(binaryPath, []byte{0x00, 0x01, 0x02}, 0644))
-	got, err := isBinaryContent(binaryPath)
-	require.NoError(t, err)
-	assert.True(t, got, "null バイトを含むファイルはバイナリ判定されるべき")
-
-	// テキストファイル
-	textPath := filepath.Join(tmpDir, "text")
-	require.NoError(t, os.WriteFile(textPath, []byte("hello\nworld\n"), 0644))
-	got, err = isBinaryContent(textPath)
-	require.NoError(t, err)
-	assert.False(t, got, "テキストファイルはバイナリ判定されないべき")
-
-	// 空ファイル
-	emptyPath := filepath.Join(tmpDir, "empty")
-	require.NoError(t, os.WriteFile(emptyPath, []byte{}, 0644))
-	got, err = isBinaryContent(emptyPath)
-	require.NoError(t, err)
-	assert.False(t, got, "空ファイルはバイナリ判定されないべき")
+	tests := []struct {
+		name    string
+		content []byte
+		want    bool
+	}{
+		{"null バイトを含むファイル", []byte{0x00, 0x01, 0x02}, true},
+		{"テキストファイル", []byte("hello\nworld\n"), false},
+		{"空ファイル", []byte{}, false},
+		// 判定に使うのは先頭 binarySniffSize バイトのみ
+		{"先頭以降の null バイト", append(bytes.Repeat([]byte("a"), binarySniffSize), 0x00), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, "file")
+			require.NoError(t, os.WriteFile(path, tt.content, 0644))
+			head, err := readHead(path)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(head), binarySniffSize)
+			assert.Equal(t, tt.want, isBinaryHead(head))
+		})
+	}
 }
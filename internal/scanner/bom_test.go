@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/config/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// utf16LE は ASCII 文字列を BOM 付きの UTF-16LE に変換するヘルパー。
+func utf16LE(s string) []byte {
+	out := []byte{0xFF, 0xFE}
+	for _, c := range []byte(s) {
+		out = append(out, c, 0x00)
+	}
+	return out
+}
+
+func TestIsBinaryHead_BOM(t *testing.T) {
+	assert.False(t, isBinaryHead(utf16LE("class A {}\r\n")), "UTF-16LE はテキスト")
+	assert.False(t, isBinaryHead([]byte{0xFE, 0xFF, 0x00, 'a'}), "UTF-16BE はテキスト")
+	assert.False(t, isBinaryHead([]byte{0xFF, 0xFE, 0x00, 0x00, 'a', 0x00, 0x00, 0x00}), "UTF-32LE はテキスト")
+	assert.False(t, isBinaryHead([]byte("\xEF\xBB\xBFpackage main\n")), "UTF-8 BOM はテキスト")
+	assert.True(t, isBinaryHead([]byte{'a', 0x00, 'b'}), "BOM のない NUL はバイナリ")
+}
+
+func TestScan_UTF16AndSkippedBinary(t *testing.T) {
+	tmpDir := t.TempDir()
+	gen := utf16LE("// Code generated by resgen. DO NOT EDIT.\r\n")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.rc"), utf16LE("#include \"resource.h\"\r\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "res.cs"), gen, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "data.dat"), []byte{0x01, 0x00, 0x02}, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "logo.png"), []byte("png"), 0644))
+
+	// デフォルトではバイナリのスキップは記録しない
+	result, err := scanRoot(t, tmpDir, &config.Config{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"app.rc", "res.cs"}, filePaths(result))
+	assert.Empty(t, result.Skipped)
+
+	cfg := &config.Config{
+		ReportSkippedBinary: true,
+		SkipGenerated:       true,
+		GeneratedPatterns:   defaults.GeneratedPatterns(),
+	}
+	result, err = scanRoot(t, tmpDir, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.rc"}, filePaths(result))
+	assert.ElementsMatch(t, []SkippedFile{
+		{Path: "data.dat", Reason: SkipReasonBinary},
+		{Path: "logo.png", Reason: SkipReasonBinary},
+		{Path: "res.cs", Reason: SkipReasonGenerated},
+	}, result.Skipped)
+}
@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/ousiassllc/linterly/internal/config"
+)
+
+// generatedSniffLines は自動生成ファイル判定で検査する先頭行数。
+// ライセンスヘッダーの後に生成マーカーが置かれるケースを考慮して余裕を持たせている。
+const generatedSniffLines = 20
+
+// スキップ理由（SkippedFile.Reason の値）。
+const (
+	SkipReasonGenerated = "generated" // skip_generated による自動生成ファイル
+	SkipReasonBinary    = "binary"    // バイナリファイル（report_skipped_binary: true の場合のみ記録）
+)
+
+// SkippedFile は走査で見つかったがチェック対象から外したファイルの情報。
+type SkippedFile struct {
+	Path   string // プロジェクトルートからの相対パス
+	Reason string // スキップ理由（SkipReasonGenerated / SkipReasonBinary）
+}
+
+// generatedMatcher は自動生成ファイルのヘッダーマーカーを判定する。
+type generatedMatcher struct {
+	patterns []*regexp.Regexp
+}
+
+// newGeneratedMatcher は設定から generatedMatcher を構築する。
+// skip_generated が無効な場合は nil を返す。
+func newGeneratedMatcher(cfg *config.Config) (*generatedMatcher, error) {
+	if !cfg.SkipGenerated {
+		return nil, nil
+	}
+	m := &generatedMatcher{}
+	for _, p := range cfg.GeneratedPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	return m, nil
+}
+
+// match はファイル先頭の generatedSniffLines 行のいずれかがパターンにマッチするかを返す。
+func (m *generatedMatcher) match(head []byte) bool {
+	if m == nil {
+		return false
+	}
+	for i := 0; i < generatedSniffLines && len(head) > 0; i++ {
+		line, rest, _ := bytes.Cut(head, []byte{'\n'})
+		head = rest
+		line = bytes.TrimRight(line, "\r")
+		for _, re := range m.patterns {
+			if re.Match(line) {
+				return true
+			}
+		}
+	}
+	return false
+}
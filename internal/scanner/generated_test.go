@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/config/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedMatcher_DefaultPatterns(t *testing.T) {
+	m, err := newGeneratedMatcher(&config.Config{
+		SkipGenerated:     true,
+		GeneratedPatterns: defaults.GeneratedPatterns(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		head     string
+		expected bool
+	}{
+		{"Go の規約", "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n", true},
+		{"CRLF 改行", "// Code generated by mockgen. DO NOT EDIT.\r\npackage mock\r\n", true},
+		{"@generated マーカー", "/**\n * @generated SignedSource<<abc>>\n */\n", true},
+		{"C# の auto-generated", "// <auto-generated>\n//     This code was generated by a tool.\n", true},
+		{"Python のヘッダー", "# This file was automatically generated by SWIG.\n", true},
+		{"DO NOT MODIFY", "/* Generated by jOOQ - DO NOT MODIFY */\n", true},
+		{"ライセンスヘッダーの後", strings.Repeat("// Copyright\n", 5) + "// Code generated by stringer. DO NOT EDIT.\n", true},
+		{"通常のコード", "package main\n\nfunc main() {}\n", false},
+		{"本文中の言及", "package db\n\n// ID is auto-generated by the database.\nvar ID int\n", false},
+		{"検査範囲外", strings.Repeat("x\n", generatedSniffLines) + "// Code generated by x. DO NOT EDIT.\n", false},
+		{"空ファイル", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, m.match([]byte(tt.head)))
+		})
+	}
+}
+
+func TestGeneratedMatcher_Disabled(t *testing.T) {
+	m, err := newGeneratedMatcher(&config.Config{
+		GeneratedPatterns: defaults.GeneratedPatterns(),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, m)
+	assert.False(t, m.match([]byte("// Code generated by x. DO NOT EDIT.\n")))
+}
+
+func TestScan_SkipGenerated(t *testing.T) {
+	tmpDir := t.TempDir()
+	gen := "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n"
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "api"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "api", "api.pb.go"), []byte(gen), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "api", "server.go"), []byte("package api\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "custom.txt"), []byte("GENERATED-BY-TOOL\n"), 0644))
+
+	// 無効の場合は通常どおりチェック対象になる
+	result, err := scanRoot(t, tmpDir, &config.Config{GeneratedPatterns: defaults.GeneratedPatterns()})
+	require.NoError(t, err)
+	assert.Contains(t, filePaths(result), "api/api.pb.go")
+	assert.Empty(t, result.Skipped)
+
+	cfg := &config.Config{
+		SkipGenerated:     true,
+		GeneratedPatterns: append(defaults.GeneratedPatterns(), `^GENERATED-BY-TOOL$`),
+	}
+	result, err = scanRoot(t, tmpDir, cfg)
+	require.NoError(t, err)
+
+	paths := filePaths(result)
+	assert.Equal(t, []string{"api/server.go"}, paths)
+	assert.ElementsMatch(t, []SkippedFile{
+		{Path: "api/api.pb.go", Reason: SkipReasonGenerated},
+		{Path: "custom.txt", Reason: SkipReasonGenerated},
+	}, result.Skipped)
+}
@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan_RespectGitattributes(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		".gitattributes":         "*.pb.go linguist-generated=true\nthird_party/** linguist-vendored\nthird_party/patched.go -linguist-vendored\n",
+		"main.go":                "package main\n",
+		"api/service.pb.go":      "package api\n",
+		"third_party/lib.go":     "package lib\n",
+		"third_party/patched.go": "package lib\n",
+		"web/.gitattributes":     "gen/** linguist-generated\n",
+		"web/gen/client.ts":      "export {}\n",
+		"web/src/app.ts":         "export {}\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	// 無効の場合は .gitattributes を参照しない
+	result, err := Scan(".", &config.Config{})
+	require.NoError(t, err)
+	assert.Contains(t, filePaths(result), "api/service.pb.go")
+
+	cfg := &config.Config{RespectGitattributes: true}
+	result, err = Scan(".", cfg)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		".gitattributes",
+		"main.go",
+		"third_party/patched.go",
+		"web/.gitattributes",
+		"web/src/app.ts",
+	}, filePaths(result))
+
+	// サブディレクトリ指定でもルートの .gitattributes が適用される
+	result, err = Scan("third_party", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"third_party/patched.go"}, filePaths(result))
+
+	result, err = Scan("web/gen", cfg)
+	require.NoError(t, err)
+	assert.Empty(t, filePaths(result))
+}
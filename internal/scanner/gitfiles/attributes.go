@@ -0,0 +1,134 @@
+package gitfiles
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// gitattributesFileName は Git の属性ファイル名。
+const gitattributesFileName = ".gitattributes"
+
+// linguistAttributes は除外対象とする GitHub Linguist の属性。
+var linguistAttributes = []string{"linguist-generated", "linguist-vendored"}
+
+// attrRule は .gitattributes の1行分のルール。
+type attrRule struct {
+	base    string          // .gitattributes が置かれたディレクトリ（プロジェクトルート相対、"." はルート）
+	pattern string          // パス パターン
+	attrs   map[string]bool // 属性名 → 値（"attr" / "attr=true" は true、"-attr" / "attr=false" は false）
+	unset   []string        // "!attr" で未指定に戻す属性
+}
+
+// Attributes はプロジェクト内の .gitattributes から読み込んだルール一覧。
+// ルールは親ディレクトリから順に追加され、後のルールほど優先される（Git と同じ）。
+type Attributes struct {
+	root  string
+	rules []attrRule
+}
+
+// NewAttributes は .gitattributes を読み込むための空のルール一覧を返す。
+// 各ディレクトリの .gitattributes は LoadDir で親ディレクトリから順に追加する。
+func NewAttributes(projectRoot string) *Attributes {
+	return &Attributes{root: projectRoot}
+}
+
+// LoadDir はディレクトリ（プロジェクトルート相対）直下の .gitattributes を読み込む。
+// ファイルが存在しない場合、または respect_gitattributes が無効（g が nil）の場合は何もしない。
+func (g *Attributes) LoadDir(relDir string) error {
+	if g == nil {
+		return nil
+	}
+	f, err := os.Open(filepath.Join(g.root, filepath.FromSlash(relDir), gitattributesFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if rule, ok := parseAttrLine(relDir, s.Text()); ok {
+			g.rules = append(g.rules, rule)
+		}
+	}
+	return s.Err()
+}
+
+// parseAttrLine は .gitattributes の1行をパースする。
+// linguist-generated / linguist-vendored を含まない行は無視する。
+func parseAttrLine(base, line string) (attrRule, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+		return attrRule{}, false
+	}
+
+	rule := attrRule{base: base, pattern: fields[0], attrs: make(map[string]bool)}
+	for _, f := range fields[1:] {
+		name, value, hasValue := strings.Cut(f, "=")
+		switch {
+		case strings.HasPrefix(name, "-"):
+			name, value = name[1:], "false"
+		case strings.HasPrefix(name, "!"):
+			name = name[1:]
+			if slices.Contains(linguistAttributes, name) {
+				rule.unset = append(rule.unset, name)
+			}
+			continue
+		case !hasValue:
+			value = "true"
+		}
+		if slices.Contains(linguistAttributes, name) {
+			rule.attrs[name] = value != "false"
+		}
+	}
+	if len(rule.attrs) == 0 && len(rule.unset) == 0 {
+		return attrRule{}, false
+	}
+	return rule, true
+}
+
+// Excluded はファイル（プロジェクトルート相対パス）が linguist-generated または
+// linguist-vendored として指定されているかを返す。
+func (g *Attributes) Excluded(relPath string) bool {
+	if g == nil {
+		return false
+	}
+	state := make(map[string]bool)
+	for _, rule := range g.rules {
+		if !rule.matches(relPath) {
+			continue
+		}
+		for name, v := range rule.attrs {
+			state[name] = v
+		}
+		for _, name := range rule.unset {
+			delete(state, name)
+		}
+	}
+	for _, v := range state {
+		if v {
+			return true
+		}
+	}
+	return false
+}
+
+// matches はルールのパターンがファイルにマッチするかを返す。
+// スラッシュを含まないパターンは任意の階層のファイル名に、含むパターンは
+// .gitattributes の置かれたディレクトリからの相対パスにマッチさせる。
+func (r attrRule) matches(relPath string) bool {
+	rel := relPath
+	if r.base != "." {
+		if !strings.HasPrefix(relPath, r.base+"/") {
+			return false
+		}
+		rel = strings.TrimPrefix(relPath, r.base+"/")
+	}
+	return MatchPattern(r.pattern, rel)
+}
@@ -0,0 +1,64 @@
+package gitfiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAttrLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		ok    bool
+		attrs map[string]bool
+		unset []string
+	}{
+		{"set", "*.pb.go linguist-generated", true, map[string]bool{"linguist-generated": true}, nil},
+		{"=true", "api/** linguist-generated=true", true, map[string]bool{"linguist-generated": true}, nil},
+		{"=false", "api/** linguist-vendored=false", true, map[string]bool{"linguist-vendored": false}, nil},
+		{"-attr", "third_party/keep.go -linguist-vendored", true, map[string]bool{"linguist-vendored": false}, nil},
+		{"!attr", "x.go !linguist-generated", true, map[string]bool{}, []string{"linguist-generated"}},
+		{"他の属性のみ", "*.sh text eol=lf", false, nil, nil},
+		{"コメント", "# *.pb.go linguist-generated", false, nil, nil},
+		{"空行", "", false, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := parseAttrLine(".", tt.line)
+			assert.Equal(t, tt.ok, ok)
+			if ok {
+				assert.Equal(t, tt.attrs, rule.attrs)
+				assert.Equal(t, tt.unset, rule.unset)
+			}
+		})
+	}
+}
+
+func TestAttrRule_Matches(t *testing.T) {
+	tests := []struct {
+		base     string
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{".", "*.pb.go", "api/v1/service.pb.go", true},
+		{".", "*.pb.go", "api/v1/service.go", false},
+		{".", "vendor/**", "vendor/github.com/x/y.go", true},
+		{".", "/vendor/**", "vendor/a.go", true},
+		{".", "vendor/**", "src/vendor/a.go", false},
+		{".", "**/gen/*.ts", "web/src/gen/api.ts", true},
+		{".", "docs/*.md", "docs/sub/a.md", false},
+		{"web", "gen/*", "web/gen/api.ts", true},
+		{"web", "gen/*", "gen/api.ts", false},
+		{"web", "*.snap", "web/__snapshots__/a.snap", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			rule := attrRule{base: tt.base, pattern: tt.pattern}
+			assert.Equal(t, tt.expected, rule.matches(tt.path))
+		})
+	}
+}
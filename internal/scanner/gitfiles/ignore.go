@@ -0,0 +1,149 @@
+package gitfiles
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	gitignore "github.com/denormal/go-gitignore"
+
+	"github.com/ousiassllc/linterly/internal/config"
+)
+
+// IgnoreFileName は Git の除外パターンファイル名。
+const IgnoreFileName = ".gitignore"
+
+// Excludes はプロジェクト全体に適用する Git の除外パターン。
+// プロジェクトルートが Git の作業ツリーのサブディレクトリの場合も、Git と同じく作業ツリーのルートを基準に評価する。
+type Excludes struct {
+	// layers は除外パターンのファイルごとのマッチャー（優先度の高い順）
+	layers []excludeLayer
+}
+
+// excludeLayer は1つの除外パターンのファイル。
+type excludeLayer struct {
+	// prefix はファイルの基準ディレクトリからプロジェクトルートへの相対パス（末尾 "/" 付き。同じ場合は空）
+	prefix  string
+	matcher gitignore.GitIgnore
+}
+
+// LoadExcludes はプロジェクトルートに適用する Git の除外パターンを読み込む。
+// 優先度の低い順に、core.excludesFile（グローバル）、.git/info/exclude、作業ツリーのルートから
+// プロジェクトルートまでの各ディレクトリの .gitignore を読み込む。作業ツリーのルートは
+// プロジェクトルートから親ディレクトリへ .git を探して決める（見つからない場合はプロジェクトルート）。
+// プロジェクトルートより下の .gitignore は含めない（走査中にディレクトリごとに読み込む）。
+func LoadExcludes(projectRoot string) (*Excludes, error) {
+	workTree, gitPath := findWorkTree(projectRoot)
+
+	// 基準ディレクトリごとのファイル（優先度の低い順）
+	type source struct{ dir, file string }
+	sources := []source{
+		{workTree, globalExcludesFile(projectRoot)},
+		{workTree, infoExcludeFile(workTree, gitPath)},
+	}
+	var dirs []string
+	for dir := projectRoot; ; dir = filepath.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+		if dir == workTree || filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	for _, dir := range dirs {
+		sources = append(sources, source{dir, filepath.Join(dir, IgnoreFileName)})
+	}
+
+	e := &Excludes{}
+	for _, src := range sources {
+		if src.file == "" {
+			continue
+		}
+		patterns, err := config.ReadIgnoreFile(src.file)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		rel, err := filepath.Rel(src.dir, projectRoot)
+		if err != nil {
+			return nil, err
+		}
+		prefix := ""
+		if rel != "." {
+			prefix = filepath.ToSlash(rel) + "/"
+		}
+		matcher := gitignore.New(strings.NewReader(strings.Join(patterns, "\n")), src.dir, nil)
+		e.layers = append([]excludeLayer{{prefix: prefix, matcher: matcher}}, e.layers...)
+	}
+	return e, nil
+}
+
+// Match はパス（プロジェクトルート相対）に最初にマッチした（最も優先度の高い）パターンを返す。
+// どのパターンにもマッチしない場合は nil を返す。
+func (e *Excludes) Match(relPath string, isDir bool) gitignore.Match {
+	for _, l := range e.layers {
+		if match := l.matcher.Relative(l.prefix+relPath, isDir); match != nil {
+			return match
+		}
+	}
+	return nil
+}
+
+// findWorkTree は dir から親ディレクトリへ .git を探し、Git の作業ツリーのルートと .git のパスを返す。
+// 見つからない場合は dir と空文字列を返す。
+func findWorkTree(dir string) (workTree, gitPath string) {
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d, filepath.Join(d, ".git")
+		}
+		if filepath.Dir(d) == d {
+			return dir, ""
+		}
+	}
+}
+
+// infoExcludeFile は作業ツリーの .git/info/exclude のパスを返す。.git がファイルの場合（git worktree・
+// サブモジュール）は git コマンドで実際のパスを求める。Git の作業ツリーでない場合は空文字列を返す。
+func infoExcludeFile(workTree, gitPath string) string {
+	if gitPath == "" {
+		return ""
+	}
+	if info, err := os.Stat(gitPath); err == nil && info.IsDir() {
+		return filepath.Join(gitPath, "info", "exclude")
+	}
+	cmd := exec.Command("git", "rev-parse", "--git-path", "info/exclude")
+	cmd.Dir = workTree
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	path := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workTree, path)
+	}
+	return path
+}
+
+// globalExcludesFile は Git の core.excludesFile のパスを返す。
+// 未設定、または git コマンドがない場合は Git のデフォルト（$XDG_CONFIG_HOME/git/ignore、
+// XDG_CONFIG_HOME が未設定なら ~/.config/git/ignore）を返す。
+func globalExcludesFile(projectRoot string) string {
+	cmd := exec.Command("git", "config", "--path", "--get", "core.excludesFile")
+	cmd.Dir = projectRoot
+	if out, err := cmd.Output(); err == nil {
+		if path := strings.TrimSpace(string(out)); path != "" {
+			return path
+		}
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "git", "ignore")
+}
@@ -0,0 +1,55 @@
+package gitfiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalExcludesFile_Default(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(home, "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	// core.excludesFile が未設定の場合は Git のデフォルトの場所
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+	assert.Equal(t, filepath.Join(home, "xdg", "git", "ignore"), globalExcludesFile(t.TempDir()))
+}
+
+func TestLoadExcludes_FromWorkTreeRoot(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(home, "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+
+	// プロジェクトルート（services/api）は作業ツリーのサブディレクトリ
+	workTree := t.TempDir()
+	files := map[string]string{
+		".git/info/exclude":     "/services/api/scratch.go\n",
+		".gitignore":            "*.log\n/services/api/build/\n/docs/\n",
+		"services/.gitignore":   "/api/tmp/\n!keep.log\n",
+		"services/api/.gitkeep": "",
+	}
+	for name, content := range files {
+		p := filepath.Join(workTree, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	e, err := LoadExcludes(filepath.Join(workTree, "services", "api"))
+	require.NoError(t, err)
+	ignored := func(relPath string, isDir bool) bool {
+		m := e.Match(relPath, isDir)
+		return m != nil && m.Ignore()
+	}
+	assert.True(t, ignored("scratch.go", false), ".git/info/exclude")
+	assert.True(t, ignored("debug.log", false), "作業ツリーのルートの .gitignore")
+	assert.True(t, ignored("build", true), "作業ツリーのルートからのパスで評価する")
+	assert.True(t, ignored("tmp", true), "途中のディレクトリの .gitignore")
+	assert.False(t, ignored("keep.log", false), "近いディレクトリの .gitignore が優先される")
+	assert.False(t, ignored("docs", true))
+	assert.False(t, ignored("main.go", false))
+}
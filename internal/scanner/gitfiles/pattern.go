@@ -0,0 +1,40 @@
+package gitfiles
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchPattern はスラッシュ区切りの相対パスがパターンにマッチするかを返す。
+// スラッシュを含まないパターンは任意の階層のファイル名に、含むパターンは基準ディレクトリからの
+// 相対パスにマッチさせる（.gitattributes と同じ規則）。"**" は0個以上の階層にマッチする。
+func MatchPattern(pattern, relPath string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(relPath))
+		return ok
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+// matchSegments はパス区切りごとにパターンを照合する。"**" は0個以上の階層にマッチする。
+func matchSegments(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchSegments(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], parts[1:])
+}
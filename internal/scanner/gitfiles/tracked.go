@@ -0,0 +1,38 @@
+package gitfiles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// TrackedFiles は Git のインデックスに登録されたディレクトリ配下のファイルを、
+// ディレクトリからの相対パス（スラッシュ区切り）で返す。
+func TrackedFiles(dir string) ([]string, error) {
+	cmd := exec.Command("git", "ls-files", "-z", "--cached")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git ls-files: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git ls-files: %w", err)
+	}
+
+	var files []string
+	for _, p := range bytes.Split(out, []byte{0}) {
+		if len(p) == 0 {
+			continue
+		}
+		// コンフリクト中のファイルはステージごとに出力されるため重複を除く
+		name := string(p)
+		if n := len(files); n > 0 && files[n-1] == name {
+			continue
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
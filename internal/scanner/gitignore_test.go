@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan_RespectGitignore(t *testing.T) {
+	tmpDir := t.TempDir()
+	home := t.TempDir()
+	files := map[string]string{
+		".gitignore":               "artifacts/\n*.log\n",
+		".git/info/exclude":        "scratch.go\n",
+		".linterlyignore":          "!keep.log\n",
+		"main.go":                  "package main\n",
+		"scratch.go":               "package main\n",
+		"debug.log":                "log\n",
+		"keep.log":                 "log\n",
+		"local.swp":                "swap\n",
+		"artifacts/out.js":         "export {}\n",
+		"web/.gitignore":           "/coverage/\n",
+		"web/.linterlyignore":      "!coverage/\n",
+		"web/coverage/report.html": "<html></html>\n",
+		"api/.gitignore":           "/coverage/\n",
+		"api/coverage/report.html": "<html></html>\n",
+		"api/handler.go":           "package api\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	// グローバルの core.excludesFile
+	globalIgnore := filepath.Join(home, "global-ignore")
+	require.NoError(t, os.WriteFile(globalIgnore, []byte("*.swp\n"), 0644))
+	gitconfig := filepath.Join(home, "gitconfig")
+	require.NoError(t, os.WriteFile(gitconfig, []byte("[core]\n\texcludesFile = "+filepath.ToSlash(globalIgnore)+"\n"), 0644))
+	t.Setenv("GIT_CONFIG_GLOBAL", gitconfig)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	// 無効の場合は .gitignore を参照しない
+	result, err := Scan(".", &config.Config{DefaultExcludes: true})
+	require.NoError(t, err)
+	assert.Contains(t, filePaths(result), "artifacts/out.js")
+
+	result, err = Scan(".", &config.Config{DefaultExcludes: true, RespectGitignore: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		".gitignore",
+		".linterlyignore",
+		"main.go",
+		// .linterlyignore で .gitignore の除外を取り消す
+		"keep.log",
+		"web/.gitignore",
+		"web/.linterlyignore",
+		"web/coverage/report.html",
+		"api/.gitignore",
+		"api/handler.go",
+	}, filePaths(result))
+}
@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/ousiassllc/linterly/internal/scanner/gitfiles"
+)
+
+// scanGitTracked は Git のインデックスに登録されたターゲット配下のファイルを対象に、walk と同じ
+// 除外・バイナリ・自動生成の判定を行う。ディレクトリを走査しないため、未追跡の node_modules 等が
+// 大きくても影響を受けない。
+func (s *scanState) scanGitTracked(absTarget string) error {
+	files, err := gitfiles.TrackedFiles(absTarget)
+	if err != nil {
+		return err
+	}
+
+	if err := s.enterTarget(absTarget); err != nil {
+		return err
+	}
+
+	for _, relFromTarget := range files {
+		abs := filepath.Join(absTarget, filepath.FromSlash(relFromTarget))
+		rel, err := s.relPath(abs)
+		if err != nil {
+			return err
+		}
+		excluded, err := s.dirExcluded(path.Dir(rel))
+		if err != nil {
+			return err
+		}
+		if excluded {
+			continue
+		}
+
+		// インデックスにあって作業ツリーで削除されたファイル、シンボリックリンク、サブモジュールはスキップ
+		info, err := os.Lstat(abs)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		if err := s.addFile(abs, rel, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
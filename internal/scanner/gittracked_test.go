@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan_GitTracked(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git が見つからない")
+	}
+
+	tmpDir := t.TempDir()
+	tracked := map[string]string{
+		".linterlyignore":        "*.pb.go\n",
+		"main.go":                "package main\n",
+		"api/service.go":         "package api\n",
+		"api/service.pb.go":      "package api\n",
+		"web/.linterlyignore":    "legacy/\n",
+		"web/app.ts":             "export {}\n",
+		"web/legacy/old.ts":      "export {}\n",
+		"web/dist/bundle.js":     "export {}\n",
+		"assets/logo.png":        "\x89PNG\r\n",
+		"deleted/in_worktree.go": "package deleted\n",
+	}
+	for name, content := range tracked {
+		p := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	// dist/ はデフォルト除外だが、インデックスからの列挙でも除外される
+	git("add", "-f", ".")
+
+	// 未追跡のファイル・インデックスにあって削除されたファイルは対象外
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "scratch.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "node_modules", "lib"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "node_modules", "lib", "index.js"), []byte("x\n"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(tmpDir, "deleted", "in_worktree.go")))
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	cfg := &config.Config{DefaultExcludes: true, GitTracked: true}
+	result, err := Scan(".", cfg)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		".linterlyignore",
+		"main.go",
+		"api/service.go",
+		"web/.linterlyignore",
+		"web/app.ts",
+	}, filePaths(result))
+	assert.ElementsMatch(t, []string{".", "api", "web"}, result.Dirs)
+
+	// サブディレクトリ指定でもプロジェクトルート相対のパスを返す
+	result, err = Scan("web", cfg)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"web/.linterlyignore", "web/app.ts"}, filePaths(result))
+}
+
+func TestScan_GitTracked_NotRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git が見つからない")
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(tmpDir))
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	_, err = Scan(".", &config.Config{GitTracked: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git ls-files")
+}
@@ -0,0 +1,177 @@
+package scanner
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	gitignore "github.com/denormal/go-gitignore"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/config/defaults"
+	"github.com/ousiassllc/linterly/internal/scanner/gitfiles"
+)
+
+// ignoreFiles はサブディレクトリに置かれた .linterlyignore（respect_gitignore 有効時は .gitignore も）のルール。
+// プロジェクトルートのファイルは buildRootRules で読み込むため含めない。
+type ignoreFiles struct {
+	root string
+	// names は読み込むファイル名。同じディレクトリのファイルは後のものほど優先される
+	names []string
+	// matchers はファイルが置かれたディレクトリ（プロジェクトルート相対）ごとのパターン
+	matchers map[string]gitignore.GitIgnore
+}
+
+// newIgnoreFiles は除外ファイルを読み込むための空の ignoreFiles を返す。
+// 各ディレクトリのファイルは loadDir で親ディレクトリから順に追加する。
+func newIgnoreFiles(projectRoot string, cfg *config.Config) *ignoreFiles {
+	f := &ignoreFiles{root: projectRoot, matchers: make(map[string]gitignore.GitIgnore)}
+	if cfg.RespectGitignore {
+		f.names = append(f.names, gitfiles.IgnoreFileName)
+	}
+	f.names = append(f.names, config.IgnoreFileName)
+	return f
+}
+
+// ancestorDirs はプロジェクトルートからターゲットの親ディレクトリまでのディレクトリ
+// （プロジェクトルート相対）を返す。ターゲット自体は走査時に読み込むため含めない。
+// ターゲットがプロジェクトルート、またはプロジェクト外の場合は空を返す。
+func ancestorDirs(projectRoot, absTarget string) ([]string, error) {
+	rel, err := filepath.Rel(projectRoot, absTarget)
+	if err != nil {
+		return nil, err
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return nil, nil
+	}
+	dirs := []string{"."}
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		dirs = append(dirs, strings.Join(parts[:i], "/"))
+	}
+	return dirs, nil
+}
+
+// loadDir はディレクトリ（プロジェクトルート相対）直下の除外ファイルを読み込む。
+// ファイルが存在しない場合、およびプロジェクトルートの場合は何もしない。
+func (f *ignoreFiles) loadDir(relDir string) error {
+	if relDir == "." {
+		return nil
+	}
+	absDir := filepath.Join(f.root, filepath.FromSlash(relDir))
+	var patterns []string
+	for _, name := range f.names {
+		p, err := readIgnoreFileIfExists(filepath.Join(absDir, name))
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, p...)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	reader := strings.NewReader(strings.Join(patterns, "\n"))
+	f.matchers[relDir] = gitignore.New(reader, absDir, nil)
+	return nil
+}
+
+// readIgnoreFileIfExists は除外ファイルのパターンを返す。ファイルが存在しない場合は nil を返す。
+func readIgnoreFileIfExists(path string) ([]string, error) {
+	patterns, err := config.ReadIgnoreFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return patterns, err
+}
+
+// excluded はパス（プロジェクトルート相対）が除外されるかを返す。
+// パスに近い（深い）ディレクトリのファイルから順に照合し、最初にマッチしたパターンで
+// 判定する（`!pattern` で親のルールを打ち消せる）。どれにもマッチしなければ root で判定する。
+func (f *ignoreFiles) excluded(root *rootRules, relPath string, isDir bool) bool {
+	if len(f.matchers) > 0 {
+		for dir := path.Dir(relPath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			m, ok := f.matchers[dir]
+			if !ok {
+				continue
+			}
+			if match := m.Relative(strings.TrimPrefix(relPath, dir+"/"), isDir); match != nil {
+				return match.Ignore()
+			}
+		}
+	}
+	match := root.match(relPath, isDir)
+	return match != nil && match.Ignore()
+}
+
+// rootRules はプロジェクトルートで適用する除外のルール。
+type rootRules struct {
+	user     gitignore.GitIgnore // .linterlyignore または設定ファイルの ignore
+	git      *gitfiles.Excludes  // Git の除外パターン（respect_gitignore: true の場合のみ）
+	defaults gitignore.GitIgnore // デフォルト除外パターン（default_excludes: true の場合のみ）
+}
+
+// buildRootRules はプロジェクトルートの除外のルールを構築する。
+func buildRootRules(projectRoot string, cfg *config.Config) (*rootRules, error) {
+	r := &rootRules{}
+	if cfg.DefaultExcludes {
+		r.defaults = buildMatcher(projectRoot, defaults.ExcludePatterns())
+	}
+	if cfg.RespectGitignore {
+		var err error
+		if r.git, err = gitfiles.LoadExcludes(projectRoot); err != nil {
+			return nil, err
+		}
+	}
+	ignorePatterns, _, err := cfg.IgnorePatterns()
+	if err != nil {
+		return nil, err
+	}
+	r.user = buildMatcher(projectRoot, ignorePatterns)
+	return r, nil
+}
+
+// match はパス（プロジェクトルート相対）にマッチしたパターンを返す。ユーザー定義の除外パターン、
+// Git の除外パターン、デフォルト除外パターンの順に照合し、最初にマッチしたものを返す
+// （後のものほど優先度が低く、`!pattern` で前のルールの除外を取り消せる）。
+func (r *rootRules) match(relPath string, isDir bool) gitignore.Match {
+	if r.user != nil {
+		if m := r.user.Relative(relPath, isDir); m != nil {
+			return m
+		}
+	}
+	if r.git != nil {
+		if m := r.git.Match(relPath, isDir); m != nil {
+			return m
+		}
+	}
+	if r.defaults != nil {
+		return r.defaults.Relative(relPath, isDir)
+	}
+	return nil
+}
+
+// buildMatcher は除外パターンから gitignore マッチャーを構築する。パターンがない場合は nil を返す。
+func buildMatcher(basePath string, patterns []string) gitignore.GitIgnore {
+	if len(patterns) == 0 {
+		return nil
+	}
+	// パターンを改行区切りの文字列にして gitignore パーサーに渡す
+	reader := strings.NewReader(strings.Join(patterns, "\n"))
+	return gitignore.New(reader, basePath, nil)
+}
+
+// shouldExclude はパスが除外パターンにマッチするかを返す。
+func shouldExclude(matcher gitignore.GitIgnore, relPath string, isDir bool) bool {
+	if matcher == nil {
+		return false
+	}
+
+	match := matcher.Relative(relPath, isDir)
+	if match == nil {
+		return false
+	}
+	return match.Ignore()
+}
@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan_NestedLinterlyIgnore(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		".linterlyignore":                "*.gen.go\nfixtures/\n",
+		"main.go":                        "package main\n",
+		"api.gen.go":                     "package main\n",
+		"packages/web/.linterlyignore":   "# web 固有の除外\n/dist/\n*.snap\n!keep.gen.go\n",
+		"packages/web/app.ts":            "export {}\n",
+		"packages/web/dist/bundle.js":    "export {}\n",
+		"packages/web/src/dist/x.ts":     "export {}\n",
+		"packages/web/src/a.snap":        "snapshot\n",
+		"packages/web/keep.gen.go":       "package web\n",
+		"packages/web/other.gen.go":      "package web\n",
+		"packages/web/fixtures/data.ts":  "export {}\n",
+		"packages/api/handler.go":        "package api\n",
+		"packages/api/handler_test.snap": "snapshot\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	cfg := &config.Config{}
+	result, err := Scan(".", cfg)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		".linterlyignore",
+		"main.go",
+		"packages/web/.linterlyignore",
+		"packages/web/app.ts",
+		// "/dist/" は .linterlyignore のディレクトリ直下のみにマッチする
+		"packages/web/src/dist/x.ts",
+		// 親の "*.gen.go" を打ち消す
+		"packages/web/keep.gen.go",
+		// packages/web の .linterlyignore は packages/api に影響しない
+		"packages/api/handler.go",
+		"packages/api/handler_test.snap",
+	}, filePaths(result))
+
+	// サブディレクトリ指定でも祖先の .linterlyignore が適用される
+	result, err = Scan("packages/web/src", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"packages/web/src/dist/x.ts"}, filePaths(result))
+}
@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadPathList は改行または NUL 区切りのパスの一覧を読み込む（--files-from 用）。
+// 入力に NUL が含まれる場合は NUL 区切り（git diff --name-only -z 等の出力）、それ以外は改行区切りとして扱う。
+// 空の要素は無視し、改行区切りの場合は行末の \r を取り除く。
+func ReadPathList(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sep, trim := "\n", "\r"
+	if bytes.IndexByte(data, 0) >= 0 {
+		sep, trim = "\x00", ""
+	}
+
+	var paths []string
+	for _, p := range strings.Split(string(data), sep) {
+		p = strings.TrimSuffix(p, trim)
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// SplitMissing は paths を存在するパスと存在しないパスに分けて返す（--files-from の一覧から
+// 作業ツリーで削除されたファイルを除く）。存在の確認に失敗したパスは存在するものとして扱う。
+func SplitMissing(paths []string) (existing, missing []string) {
+	for _, p := range paths {
+		if _, err := os.Stat(p); errors.Is(err, os.ErrNotExist) {
+			missing = append(missing, p)
+			continue
+		}
+		existing = append(existing, p)
+	}
+	return existing, missing
+}
+
+// ReadPathListFile は name のファイルからパスの一覧を読み込む。name が "-" の場合は stdin から読み込む。
+func ReadPathListFile(name string, stdin io.Reader) ([]string, error) {
+	if name == "-" {
+		return ReadPathList(stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadPathList(f)
+}
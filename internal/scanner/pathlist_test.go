@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPathList(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "改行区切り", input: "a.go\nsrc/b.go\n", want: []string{"a.go", "src/b.go"}},
+		{name: "CRLF と空行", input: "a.go\r\n\r\nb.go", want: []string{"a.go", "b.go"}},
+		{name: "NUL 区切り（改行を含むパス）", input: "a.go\x00new\nline.go\x00", want: []string{"a.go", "new\nline.go"}},
+		{name: "空", input: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadPathList(strings.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScanPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, content := range map[string]string{
+		".linterlyignore":  "*.gen.go\n",
+		"main.go":          "package main\n",
+		"api/service.go":   "package api\n",
+		"api/types.gen.go": "package api\n",
+		"web/app.ts":       "export {}\n",
+		"vendor/lib/x.go":  "package lib\n",
+	} {
+		p := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	cfg := &config.Config{DefaultExcludes: true}
+	result, err := ScanPaths([]string{"api", "main.go", "api/service.go", "./main.go", "api/types.gen.go", "vendor/lib/x.go"}, cfg)
+	require.NoError(t, err)
+
+	// パスはプロジェクトルート相対で、重複・除外パターン・除外ディレクトリ配下のファイルは含まない
+	var paths []string
+	for _, f := range result.Files {
+		paths = append(paths, f.Path)
+	}
+	assert.ElementsMatch(t, []string{"api/service.go", "main.go"}, paths)
+	// ディレクトリの合計行数のチェックは走査したディレクトリのみ
+	assert.Equal(t, []string{"api"}, result.Dirs)
+	assert.Equal(t, tmpDir, result.Base)
+
+	// ターゲットが1つのディレクトリの場合もプロジェクトルート相対
+	result, err = ScanPaths([]string{"web"}, cfg)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "web/app.ts", result.Files[0].Path)
+	assert.Equal(t, []string{"web"}, result.Dirs)
+	assert.Equal(t, tmpDir, result.Base)
+
+	// 存在しないパスはエラー
+	_, err = ScanPaths([]string{"main.go", "missing.go"}, cfg)
+	assert.Error(t, err)
+}
+
+func TestSplitMissing(t *testing.T) {
+	tmpDir := t.TempDir()
+	kept := filepath.Join(tmpDir, "kept.go")
+	require.NoError(t, os.WriteFile(kept, []byte("package main\n"), 0644))
+
+	existing, missing := SplitMissing([]string{kept, filepath.Join(tmpDir, "deleted.go"), tmpDir})
+	assert.Equal(t, []string{kept, tmpDir}, existing)
+	assert.Equal(t, []string{filepath.Join(tmpDir, "deleted.go")}, missing)
+}
@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// relPath はパスのプロジェクトルート相対パス（FileEntry・ignore マッチング用）をスラッシュ区切りで返す。
+func (s *scanState) relPath(path string) (string, error) {
+	rel, err := filepath.Rel(s.projectRoot, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// enterTarget はターゲットのディレクトリとプロジェクトルートからの祖先のディレクトリについて、
+// 配下の判定に使うファイルを読み込む。これらのディレクトリは除外の判定を行わない。
+func (s *scanState) enterTarget(absTarget string) error {
+	ancestors, err := ancestorDirs(s.projectRoot, absTarget)
+	if err != nil {
+		return err
+	}
+	relFromRoot, err := s.relPath(absTarget)
+	if err != nil {
+		return err
+	}
+	s.tests.enterRoot(relFromRoot)
+	for _, dir := range append(ancestors, relFromRoot) {
+		if _, ok := s.dirs[dir]; ok {
+			continue
+		}
+		s.dirs[dir] = false
+		if err := s.loadDir(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enterDir はディレクトリが除外されるかを返す。除外されない場合は配下の判定に使うファイルを読み込む。
+func (s *scanState) enterDir(relFromRoot string) (excluded bool, err error) {
+	if excluded, ok := s.dirs[relFromRoot]; ok {
+		return excluded, nil
+	}
+	excluded = s.ignores.excluded(s.rules, relFromRoot, true)
+	s.dirs[relFromRoot] = excluded
+	if excluded {
+		return true, nil
+	}
+	s.tests.enterDir(relFromRoot)
+	return false, s.loadDir(relFromRoot)
+}
+
+// loadDir はディレクトリ（プロジェクトルート相対）直下の .linterlyignore・.gitattributes・設定ファイル等を読み込む。
+func (s *scanState) loadDir(relFromRoot string) error {
+	if err := s.ignores.loadDir(relFromRoot); err != nil {
+		return err
+	}
+	if err := s.scopes.LoadDir(relFromRoot); err != nil {
+		return err
+	}
+	return s.attrs.LoadDir(relFromRoot)
+}
+
+// dirExcluded はディレクトリ（プロジェクトルート相対）またはその祖先が除外されるかを、
+// プロジェクトルート側から順に enterDir して返す。プロジェクトルート・プロジェクト外のディレクトリは
+// 除外の判定を行わない。
+func (s *scanState) dirExcluded(relDir string) (bool, error) {
+	if excluded, ok := s.dirs[relDir]; ok {
+		return excluded, nil
+	}
+	if relDir == "." || relDir == ".." || strings.HasPrefix(relDir, "../") {
+		s.dirs[relDir] = false
+		return false, s.loadDir(relDir)
+	}
+	excluded, err := s.dirExcluded(path.Dir(relDir))
+	if err != nil {
+		return false, err
+	}
+	if excluded {
+		s.dirs[relDir] = true
+		return true, nil
+	}
+	return s.enterDir(relDir)
+}
+
+// addListedFile は引数等で指定されたファイル（または Git のインデックスのファイル）を、
+// 祖先のディレクトリの除外も含めて判定し、チェック対象であれば結果に追加する。
+// ディレクトリの合計行数のチェックには含めない。
+func (s *scanState) addListedFile(abs string) error {
+	rel, err := s.relPath(abs)
+	if err != nil {
+		return err
+	}
+	excluded, err := s.dirExcluded(path.Dir(rel))
+	if err != nil || excluded {
+		return err
+	}
+	return s.addFile(abs, rel, false)
+}
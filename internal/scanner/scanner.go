@@ -2,75 +2,174 @@ package scanner
 
 import (
 	"os"
+	"path"
 	"path/filepath"
-	"strings"
-
-	gitignore "github.com/denormal/go-gitignore"
 
 	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/config/scopes"
+	"github.com/ousiassllc/linterly/internal/lines"
+	"github.com/ousiassllc/linterly/internal/scanner/gitfiles"
 )
 
 // FileEntry は走査で見つかったファイルの情報。
 type FileEntry struct {
-	Path string // ターゲットパスからの相対パス
+	Path string // プロジェクトルート（ScanResult.Base）からの相対パス
 	Dir  string // ファイルが属するディレクトリ（相対パス）
+	Test bool   // test_patterns にマッチするテストファイル
+	// Config はネストした設定ファイルのスコープ内のファイルに適用する設定（プロジェクトルートの設定の場合は nil）
+	Config *config.Config
 }
 
 // ScanResult は走査結果。
 type ScanResult struct {
+	// Base は Files・Dirs の相対パスの基準ディレクトリ（絶対パス）。ターゲットによらずプロジェクトルート
+	Base  string
 	Files []FileEntry
 	Dirs  []string // チェック対象のディレクトリ一覧（重複なし）
+	// DirConfigs は Dirs のうちネストした設定ファイルのスコープ内のディレクトリに適用する設定
+	DirConfigs map[string]*config.Config
+	// Skipped は skip_generated 等によりチェック対象から外したファイル
+	Skipped []SkippedFile
+}
+
+// skip はチェック対象から外したファイルを記録する。
+func (r *ScanResult) skip(path, reason string) {
+	r.Skipped = append(r.Skipped, SkippedFile{Path: path, Reason: reason})
 }
 
 // Scan は指定パスを走査し、除外パターンを適用した結果を返す。
 func Scan(targetPath string, cfg *config.Config) (*ScanResult, error) {
-	absTarget, err := filepath.Abs(targetPath)
+	return ScanPaths([]string{targetPath}, cfg)
+}
+
+// ScanPaths は複数のパス（ディレクトリまたはファイル）を走査し、1つの結果にまとめて返す。
+// 複数のパスに含まれるファイルは1回だけ結果に含める。
+// ディレクトリは再帰的に走査し、git_tracked: true の場合は Git のインデックスに登録されたファイルを対象とする。
+// ファイルはそのままチェック対象とし（除外パターン等は適用する）、ディレクトリの合計行数のチェックには含めない。
+func ScanPaths(targets []string, cfg *config.Config) (*ScanResult, error) {
+	// プロジェクトルート（設定ファイル・.linterlyignore の基準ディレクトリ）
+	projectRoot := cfg.ProjectRoot
+	if projectRoot == "" {
+		var err error
+		if projectRoot, err = os.Getwd(); err != nil {
+			return nil, err
+		}
+	}
+
+	type target struct {
+		abs  string
+		info os.FileInfo
+	}
+	list := make([]target, len(targets))
+	for i, t := range targets {
+		abs, err := filepath.Abs(t)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = target{abs: abs, info: info}
+	}
+
+	s, err := newScanState(projectRoot, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	// プロジェクトルート（設定ファイル・.linterlyignore の基準ディレクトリ）
-	projectRoot, err := os.Getwd()
+	for _, t := range list {
+		switch {
+		case !t.info.IsDir():
+			err = s.addListedFile(t.abs)
+		case cfg.GitTracked:
+			err = s.scanGitTracked(t.abs)
+		default:
+			err = s.walk(t.abs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.result, nil
+}
+
+// scanState は走査中の状態（除外・判定に使うマッチャーと結果）。
+type scanState struct {
+	cfg         *config.Config
+	projectRoot string
+	rules       *rootRules
+	generated   *generatedMatcher
+	tests       *testMatcher
+	ignores     *ignoreFiles
+	attrs       *gitfiles.Attributes
+	scopes      *scopes.Tree
+	result      *ScanResult
+	dirSet      map[string]bool
+	// dirs はディレクトリ（プロジェクトルート相対）ごとの除外の判定結果。
+	// 登録済みのディレクトリは除外ファイル等の読み込みも済んでいる
+	dirs map[string]bool
+	// seen は結果に追加済みのファイル（プロジェクトルート相対）
+	seen map[string]bool
+}
+
+// newScanState は設定から除外・判定に使うマッチャーを構築する。
+func newScanState(projectRoot string, cfg *config.Config) (*scanState, error) {
+	rules, err := buildRootRules(projectRoot, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	matcher, err := buildMatcher(projectRoot, cfg)
+	generated, err := newGeneratedMatcher(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	result := &ScanResult{}
-	dirSet := make(map[string]bool)
+	var attrs *gitfiles.Attributes
+	if cfg.RespectGitattributes {
+		attrs = gitfiles.NewAttributes(projectRoot)
+	}
+
+	return &scanState{
+		cfg:         cfg,
+		projectRoot: projectRoot,
+		rules:       rules,
+		generated:   generated,
+		tests:       newTestMatcher(projectRoot, cfg),
+		ignores:     newIgnoreFiles(projectRoot, cfg),
+		attrs:       attrs,
+		scopes:      scopes.New(projectRoot, cfg),
+		result:      &ScanResult{Base: projectRoot},
+		dirSet:      make(map[string]bool),
+		dirs:        make(map[string]bool),
+		seen:        make(map[string]bool),
+	}, nil
+}
 
-	err = filepath.Walk(absTarget, func(path string, info os.FileInfo, walkErr error) error {
+// walk はターゲットディレクトリを再帰的に走査する。
+func (s *scanState) walk(absTarget string) error {
+	return filepath.Walk(absTarget, func(path string, info os.FileInfo, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
 
-		// ターゲット相対パス（FileEntry 用）
-		relFromTarget, err := filepath.Rel(absTarget, path)
+		rel, err := s.relPath(path)
 		if err != nil {
 			return err
 		}
 
-		// ルートディレクトリ自体はスキップ
-		if relFromTarget == "." {
-			return nil
+		// ターゲットのディレクトリ自体は除外の判定を行わない（.linterlyignore・.gitattributes の読み込みのみ行う）
+		if path == absTarget {
+			return s.enterTarget(absTarget)
 		}
 
-		// プロジェクトルート相対パス（ignore マッチング用）
-		relFromRoot, err := filepath.Rel(projectRoot, path)
-		if err != nil {
-			return err
-		}
-
-		// スラッシュ区切りに正規化
-		relFromTarget = filepath.ToSlash(relFromTarget)
-		relFromRoot = filepath.ToSlash(relFromRoot)
-
 		if info.IsDir() {
-			if shouldExclude(matcher, relFromRoot, true) {
+			excluded, err := s.enterDir(rel)
+			if err != nil {
+				return err
+			}
+			if excluded {
 				return filepath.SkipDir
 			}
 			return nil
@@ -81,81 +180,68 @@ func Scan(targetPath string, cfg *config.Config) (*ScanResult, error) {
 			return nil
 		}
 
-		// ファイル
-		if shouldExclude(matcher, relFromRoot, false) {
-			return nil
-		}
-
-		// バイナリファイルはスキップ
-		binary, err := isBinary(path)
-		if err != nil {
-			return err
-		}
-		if binary {
-			return nil
-		}
-
-		dir := filepath.ToSlash(filepath.Dir(relFromTarget))
-		if dir == "." {
-			dir = "."
-		}
-
-		result.Files = append(result.Files, FileEntry{
-			Path: relFromTarget,
-			Dir:  dir,
-		})
-
-		if !dirSet[dir] {
-			dirSet[dir] = true
-			result.Dirs = append(result.Dirs, dir)
-		}
-
-		return nil
+		return s.addFile(path, rel, true)
 	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
 }
 
-// buildMatcher は除外パターンから gitignore マッチャーを構築する。
-func buildMatcher(basePath string, cfg *config.Config) (gitignore.GitIgnore, error) {
-	var patterns []string
+// addFile はファイルに除外パターン・バイナリ・自動生成の判定を行い、チェック対象であれば結果に追加する。
+// addDir が true の場合は、ファイルのディレクトリをディレクトリの合計行数のチェック対象に加える。
+func (s *scanState) addFile(abs, rel string, addDir bool) error {
+	if s.seen[rel] || s.ignores.excluded(s.rules, rel, false) {
+		return nil
+	}
+	s.seen[rel] = true
 
-	// デフォルト除外パターン
-	if cfg.DefaultExcludes {
-		patterns = append(patterns, config.DefaultExcludePatterns()...)
+	// .gitattributes で linguist-generated / linguist-vendored 指定のファイルはスキップ
+	if s.attrs.Excluded(rel) {
+		return nil
 	}
 
-	// ユーザー定義の除外パターン
-	ignorePatterns, _, err := cfg.IgnorePatterns()
-	if err != nil {
-		return nil, err
+	// バイナリファイルはスキップ（拡張子で判定できない場合は先頭を読み取る）
+	var head []byte
+	binary := isBinaryExtension(abs)
+	if !binary {
+		var err error
+		head, err = readHead(abs)
+		if err != nil {
+			return err
+		}
+		binary = isBinaryHead(head)
+	}
+	if binary {
+		if s.cfg.ReportSkippedBinary {
+			s.result.skip(rel, SkipReasonBinary)
+		}
+		return nil
 	}
-	patterns = append(patterns, ignorePatterns...)
 
-	if len(patterns) == 0 {
-		return nil, nil
+	// 自動生成ファイルはスキップ（skip_generated: true の場合のみ）
+	if s.generated.match(lines.DecodeBytes(head)) {
+		s.result.skip(rel, SkipReasonGenerated)
+		return nil
 	}
 
-	// パターンを改行区切りの文字列にして gitignore パーサーに渡す
-	content := strings.Join(patterns, "\n")
-	reader := strings.NewReader(content)
+	dir := path.Dir(rel)
+	// ネストした設定ファイルのスコープ内であればその設定
+	nested := s.scopes.Nested(dir)
 
-	return gitignore.New(reader, basePath, nil), nil
-}
+	s.result.Files = append(s.result.Files, FileEntry{
+		Path:   rel,
+		Dir:    dir,
+		Test:   s.tests.match(rel),
+		Config: nested,
+	})
 
-// shouldExclude はパスが除外パターンにマッチするかを返す。
-func shouldExclude(matcher gitignore.GitIgnore, relPath string, isDir bool) bool {
-	if matcher == nil {
-		return false
+	if addDir && !s.dirSet[dir] {
+		s.dirSet[dir] = true
+		s.result.Dirs = append(s.result.Dirs, dir)
+		if nested != nil {
+			if s.result.DirConfigs == nil {
+				s.result.DirConfigs = make(map[string]*config.Config)
+			}
+			s.result.DirConfigs[dir] = nested
+		}
 	}
 
-	match := matcher.Relative(relPath, isDir)
-	if match == nil {
-		return false
-	}
-	return match.Ignore()
+	return nil
 }
@@ -11,13 +11,22 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// scanRoot は dir をプロジェクトルートとして dir を走査するヘルパー。
+func scanRoot(t *testing.T, dir string, cfg *config.Config) (*ScanResult, error) {
+	t.Helper()
+	root, err := filepath.Abs(dir)
+	require.NoError(t, err)
+	cfg.ProjectRoot = root
+	return Scan(dir, cfg)
+}
+
 func TestScan_BasicScan(t *testing.T) {
 	cfg := &config.Config{
 		DefaultExcludes: false,
 		Ignore:          []string{},
 	}
 
-	result, err := Scan("testdata/project", cfg)
+	result, err := scanRoot(t, "testdata/project", cfg)
 	require.NoError(t, err)
 
 	// vendor, node_modules, .git, build のファイルも含まれる
@@ -39,7 +48,7 @@ func TestScan_DefaultExcludes(t *testing.T) {
 		Ignore:          []string{},
 	}
 
-	result, err := Scan("testdata/project", cfg)
+	result, err := scanRoot(t, "testdata/project", cfg)
 	require.NoError(t, err)
 
 	paths := filePaths(result)
@@ -60,7 +69,7 @@ func TestScan_CustomIgnorePatterns(t *testing.T) {
 		Ignore:          []string{"src/"},
 	}
 
-	result, err := Scan("testdata/project", cfg)
+	result, err := scanRoot(t, "testdata/project", cfg)
 	require.NoError(t, err)
 
 	paths := filePaths(result)
@@ -75,7 +84,7 @@ func TestScan_DirsContainsAllRelevantDirs(t *testing.T) {
 		Ignore:          []string{},
 	}
 
-	result, err := Scan("testdata/project", cfg)
+	result, err := scanRoot(t, "testdata/project", cfg)
 	require.NoError(t, err)
 
 	sort.Strings(result.Dirs)
@@ -89,7 +98,7 @@ func TestScan_FileEntryHasCorrectDir(t *testing.T) {
 		Ignore:          []string{},
 	}
 
-	result, err := Scan("testdata/project", cfg)
+	result, err := scanRoot(t, "testdata/project", cfg)
 	require.NoError(t, err)
 
 	for _, f := range result.Files {
@@ -118,7 +127,7 @@ func TestScan_EmptyDirectory(t *testing.T) {
 		DefaultExcludes: false,
 	}
 
-	result, err := Scan(tmpDir, cfg)
+	result, err := scanRoot(t, tmpDir, cfg)
 	require.NoError(t, err)
 	assert.Empty(t, result.Files)
 	assert.Empty(t, result.Dirs)
@@ -143,7 +152,7 @@ func TestScan_SymlinksNotFollowed(t *testing.T) {
 		DefaultExcludes: false,
 	}
 
-	result, err := Scan(tmpDir, cfg)
+	result, err := scanRoot(t, tmpDir, cfg)
 	require.NoError(t, err)
 
 	paths := filePaths(result)
@@ -184,8 +193,8 @@ func TestScan_SubdirTargetWithPathIgnorePattern(t *testing.T) {
 	result, err = Scan("subdir", cfg)
 	require.NoError(t, err)
 	paths = filePaths(result)
-	assert.NotContains(t, paths, "file.go", "サブディレクトリ指定でも除外されるべき")
-	assert.Contains(t, paths, "keep.go")
+	assert.NotContains(t, paths, "subdir/file.go", "サブディレクトリ指定でも除外されるべき")
+	assert.Contains(t, paths, "subdir/keep.go")
 }
 
 func TestScan_SubdirTargetWithDirIgnorePattern(t *testing.T) {
@@ -214,8 +223,8 @@ func TestScan_SubdirTargetWithDirIgnorePattern(t *testing.T) {
 	result, err := Scan("pkg", cfg)
 	require.NoError(t, err)
 	paths := filePaths(result)
-	assert.NotContains(t, paths, "generated/code.go", "サブディレクトリ指定でも除外されるべき")
-	assert.Contains(t, paths, "src/app.go")
+	assert.NotContains(t, paths, "pkg/generated/code.go", "サブディレクトリ指定でも除外されるべき")
+	assert.Contains(t, paths, "pkg/src/app.go")
 }
 
 func filePaths(result *ScanResult) []string {
@@ -225,3 +234,63 @@ func filePaths(result *ScanResult) []string {
 	}
 	return paths
 }
+
+func TestScan_ProjectRootFromConfig(t *testing.T) {
+	// 設定ファイルが親ディレクトリにある場合、サブディレクトリから実行しても
+	// パターンは ProjectRoot を基準に評価される
+	tmpDir := t.TempDir()
+	subdir := filepath.Join(tmpDir, "subdir")
+	require.NoError(t, os.MkdirAll(subdir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(subdir, "file.go"), []byte("package sub"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(subdir, "keep.go"), []byte("package sub"), 0644))
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(subdir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	cfg := &config.Config{Ignore: []string{"subdir/file.go"}, ProjectRoot: tmpDir}
+
+	// ディレクトリ・ファイルのいずれを指定してもパスはプロジェクトルート相対
+	result, err := Scan(".", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"subdir/keep.go"}, filePaths(result))
+	assert.Equal(t, []string{"subdir"}, result.Dirs)
+
+	result, err = ScanPaths([]string{"keep.go", "file.go"}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"subdir/keep.go"}, filePaths(result))
+}
+
+func TestScan_NestedConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, content := range map[string]string{
+		"main.go":                "package main\n",
+		"apps/web/.linterly.yml": "rules:\n  max_lines_per_file: 500\n",
+		"apps/web/src/app.ts":    "export {}\n",
+	} {
+		p := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	cfg := &config.Config{
+		Rules:       config.Rules{MaxLinesPerFile: 300, MaxLinesPerDirectory: 2000},
+		CountMode:   config.CountModeAll,
+		Language:    "en",
+		ProjectRoot: tmpDir,
+	}
+	result, err := scanRoot(t, tmpDir, cfg)
+	require.NoError(t, err)
+
+	// スコープ内のファイル・ディレクトリにはスコープの設定、それ以外は nil（プロジェクトルートの設定）
+	configs := make(map[string]*config.Config)
+	for _, f := range result.Files {
+		configs[f.Path] = f.Config
+	}
+	assert.Nil(t, configs["main.go"])
+	require.NotNil(t, configs["apps/web/src/app.ts"])
+	assert.Equal(t, 500, configs["apps/web/src/app.ts"].Rules.MaxLinesPerFile)
+	assert.Same(t, configs["apps/web/src/app.ts"], result.DirConfigs["apps/web/src"])
+	assert.NotContains(t, result.DirConfigs, ".")
+}
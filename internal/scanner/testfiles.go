@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"path"
+	"strings"
+
+	gitignore "github.com/denormal/go-gitignore"
+
+	"github.com/ousiassllc/linterly/internal/config"
+)
+
+// testMatcher は test_patterns によりテストファイルを判定する。
+type testMatcher struct {
+	patterns gitignore.GitIgnore
+	// dirs はテストディレクトリ（パターンにマッチしたディレクトリとその配下）
+	dirs map[string]bool
+}
+
+// newTestMatcher は設定から testMatcher を構築する。パターンがない場合は nil を返す。
+func newTestMatcher(basePath string, cfg *config.Config) *testMatcher {
+	if len(cfg.TestPatterns) == 0 {
+		return nil
+	}
+	reader := strings.NewReader(strings.Join(cfg.TestPatterns, "\n"))
+	return &testMatcher{
+		patterns: gitignore.New(reader, basePath, nil),
+		dirs:     make(map[string]bool),
+	}
+}
+
+// enterDir は走査中のディレクトリがテストディレクトリかを記録する。
+// relPath はプロジェクトルートからの相対パス。
+func (m *testMatcher) enterDir(relPath string) {
+	if m == nil {
+		return
+	}
+	if m.dirs[path.Dir(relPath)] || shouldExclude(m.patterns, relPath, true) {
+		m.dirs[relPath] = true
+	}
+}
+
+// enterRoot は走査の起点ディレクトリについて、祖先のディレクトリも含めて enterDir を行う。
+func (m *testMatcher) enterRoot(relPath string) {
+	if m == nil || relPath == "." {
+		return
+	}
+	parts := strings.Split(relPath, "/")
+	for i := range parts {
+		m.enterDir(strings.Join(parts[:i+1], "/"))
+	}
+}
+
+// match はファイルがテストファイルかを返す。relPath はプロジェクトルートからの相対パス。
+func (m *testMatcher) match(relPath string) bool {
+	if m == nil {
+		return false
+	}
+	return m.dirs[path.Dir(relPath)] || shouldExclude(m.patterns, relPath, false)
+}
@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ousiassllc/linterly/internal/config"
+	"github.com/ousiassllc/linterly/internal/config/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan_TestFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := []string{
+		"pkg/server.go",
+		"pkg/server_test.go",
+		"web/app.ts",
+		"web/app.spec.ts",
+		"web/__tests__/helpers.ts",
+		"py/test_models.py",
+		"py/models.py",
+		"tests/unit/check.sh",
+	}
+	for _, f := range files {
+		path := filepath.Join(tmpDir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("x\n"), 0644))
+	}
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	cfg := &config.Config{TestPatterns: defaults.TestPatterns()}
+
+	result, err := Scan(".", cfg)
+	require.NoError(t, err)
+	tests := make(map[string]bool)
+	for _, f := range result.Files {
+		tests[f.Path] = f.Test
+	}
+	assert.Equal(t, map[string]bool{
+		"pkg/server.go":            false,
+		"pkg/server_test.go":       true,
+		"web/app.ts":               false,
+		"web/app.spec.ts":          true,
+		"web/__tests__/helpers.ts": true,
+		"py/test_models.py":        true,
+		"py/models.py":             false,
+		"tests/unit/check.sh":      true,
+	}, tests)
+
+	// テストディレクトリの配下をターゲットにした場合もテストとみなす
+	result, err = Scan("tests/unit", cfg)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.True(t, result.Files[0].Test)
+
+	// パターンが空の場合はすべて本番コード
+	result, err = Scan(".", &config.Config{})
+	require.NoError(t, err)
+	for _, f := range result.Files {
+		assert.False(t, f.Test, f.Path)
+	}
+}